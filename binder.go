@@ -1,6 +1,7 @@
 package configify
 
 import (
+	"errors"
 	"reflect"
 	"regexp"
 	"strings"
@@ -9,12 +10,26 @@ import (
 
 var typeDuration = reflect.TypeOf(time.Duration(0))
 var typeTime = reflect.TypeOf(time.Time{})
+var typeStringSlice = reflect.TypeOf([]string{})
+var typeByteSlice = reflect.TypeOf([]byte{})
 
 // Binder defines a component that can overlay config/source values onto an existing struct of yours.
 // This lets you have strongly-typed config struct instances in your code that you can pass around
 // rather than pulling off individual config values one by one from a source.
 type Binder interface {
+	// Bind overlays the source's values onto your struct, quietly leaving any field alone when its
+	// value is missing from the source or can't be converted to the field's type.
 	Bind(out interface{})
+
+	// BindE behaves exactly like Bind, except that it also returns a *BindError that describes every
+	// field whose value existed in the source but could not be converted to the field's type. The
+	// fields that bound successfully are still populated even when this returns an error.
+	BindE(out interface{}) error
+
+	// BindReport behaves exactly like BindE, but it also returns a Report describing where each
+	// field's value came from (which key, which source, and whether it was a default value). This
+	// is great for logging your effective configuration at startup.
+	BindReport(out interface{}) (Report, error)
 }
 
 // NewBinder creates the standard binder which maps values from your Source to the fields on
@@ -29,181 +44,578 @@ func NewBinder(source Source) Binder {
 type standardBinder struct {
 	Source
 	emptySource
+	errors *BindError
+	// allocating tracks the struct types of the nil pointers we're currently allocating.
+	allocating []reflect.Type
+	// shallow indicates that we're only checking whether a struct has values of its own, so we
+	// shouldn't allocate any more self-referencing pointers inside of it.
+	shallow bool
+	// report, when not nil, collects the origin of every field's value as we bind them.
+	report *Report
+	// secret indicates that we're binding the fields of a struct that was marked as a secret, so
+	// every one of its fields is a secret, too.
+	secret bool
 }
 
 func (b standardBinder) Bind(out interface{}) {
-	if b.Source != nil {
-		b.bindPrefix(out, "")
+	_ = b.BindE(out)
+}
+
+func (b standardBinder) BindE(out interface{}) error {
+	if b.Source == nil {
+		return nil
 	}
+
+	// The binder is passed by value, so this only tracks the errors for this specific call.
+	b.errors = &BindError{}
+	b.bindPrefix(out, "", "")
+	if len(b.errors.Fields) > 0 {
+		return b.errors
+	}
+	return nil
+}
+
+func (b standardBinder) BindReport(out interface{}) (Report, error) {
+	b.report = &Report{}
+	err := b.BindE(out)
+	return *b.report, err
 }
 
-func (b standardBinder) bindPrefix(out interface{}, prefix string) {
+// bindPrefix populates all of the fields in the struct that 'out' points to. The 'prefix' is the
+// config key of the struct itself (empty for the root struct) and 'path' is the Go-style path to
+// the struct (e.g. "HTTP.TLS") which we use to report errors. The result indicates whether the
+// source supplied a value for at least one of the struct's fields.
+func (b standardBinder) bindPrefix(out interface{}, prefix string, path string) bool {
 	outType := reflect.TypeOf(out).Elem()
 	outValue := reflect.ValueOf(out).Elem()
-	b.bindPrefixWithType(out, prefix, outType, outValue)
+	return b.bindPrefixWithType(out, prefix, path, outType, outValue)
 }
 
-func (b standardBinder) bindPrefixWithType(_ interface{}, prefix string, outType reflect.Type, outValue reflect.Value) {
+func (b standardBinder) bindPrefixWithType(_ interface{}, prefix string, path string, outType reflect.Type, outValue reflect.Value) bool {
+	found := false
 	for i := 0; i < outType.NumField(); i++ {
 		field := outType.Field(i)
+		if !field.IsExported() {
+			// We can't set unexported fields, so there's nothing to bind, validate or report.
+			continue
+		}
 		value := outValue.Field(i)
 		key := b.Source.Options().Namespace.Join(prefix, b.resolveName(field))
+		fieldPath := joinPath(path, field.Name)
+		options := b.resolveOptions(field)
+		errorCount := len(b.errors.Fields)
+		bound, usedDefault := false, false
+
+		// Nested structs inherit the secret-ness of their parent field so that we redact their values.
+		secret := b.secret || options.secret || isSecretType(field.Type)
+		fieldBinder := b
+		fieldBinder.secret = secret
+
+		switch {
+		case fieldBinder.updateValue(field, value, key, fieldPath):
+			found, bound = true, true
+		case b.checkInvalid(field.Type, key, fieldPath):
+			// The value was there, but it was garbage. We've already recorded the error.
+		case len(b.errors.Fields) > errorCount:
+			// Nested structs and prefixed maps record errors for their own keys (e.g. an invalid
+			// "TLS_PORT"), so the field isn't missing and we don't want a default clobbering it.
+		case options.hasDefault:
+			usedDefault = b.updateDefault(field, value, key, fieldPath, options.defaultValue)
+		case options.required:
+			b.errors.add(FieldError{
+				Field: fieldPath,
+				Key:   b.qualify(key),
+				Type:  field.Type,
+				Err:   ErrRequired,
+			})
+		}
+
+		// There's no sense in validating values that we already know are bad/missing.
+		if len(b.errors.Fields) == errorCount {
+			b.validateField(field, value, key, fieldPath)
+		}
+		// Never leak the values of secrets in error messages.
+		if secret {
+			for i := errorCount; i < len(b.errors.Fields); i++ {
+				b.errors.Fields[i].Value = redactValue(b.errors.Fields[i].Value)
+			}
+		}
+		// Nested structs report each of their own fields instead.
+		if b.report != nil && !isNestedStruct(field.Type) {
+			b.reportField(field, value, key, fieldPath, bound, usedDefault, secret)
+		}
+	}
+
+	b.validateStruct(outValue, prefix, path)
+	return found
+}
 
-		b.updateValue(field, value, key)
+// qualify returns the fully qualified key that the source resolves for the given key (e.g. "HTTP_PORT"
+// becomes "MYAPP_HTTP_PORT"). We ask the source itself rather than its options since sources like
+// Chain delegate to other sources that apply namespaces of their own.
+func (b standardBinder) qualify(key string) string {
+	return Explain(b.Source, key).Key
+}
+
+// checkInvalid is called when we were unable to populate a field. We want to distinguish between
+// values that were simply missing from the source and ones that were there but that we couldn't
+// convert to the field's type, so we record an error if the raw value exists. The result indicates
+// whether we found (and recorded) an invalid value.
+func (b standardBinder) checkInvalid(fieldType reflect.Type, key string, path string) bool {
+	isStruct := fieldType.Kind() == reflect.Struct || (fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct)
+	if isStruct && decoderFor(fieldType) == nil {
+		return false
 	}
+	raw, ok := b.Source.String(key)
+	if !ok {
+		return false
+	}
+	b.errors.add(FieldError{
+		Field: path,
+		Key:   b.qualify(key),
+		Value: raw,
+		Type:  fieldType,
+		Err:   ErrInvalidValue,
+	})
+	return true
 }
 
-func (b standardBinder) updateValue(field reflect.StructField, value reflect.Value, key string) {
+// updateDefault populates the field using the default value from its struct tag, parsing it exactly
+// like we would if the source had supplied that raw value. The result indicates whether the default
+// value was valid.
+func (b standardBinder) updateDefault(field reflect.StructField, value reflect.Value, key string, path string, defaultValue string) bool {
+	defaults := standardBinder{
+		Source: textSource(b.Source.Options(), defaultValue),
+		errors: b.errors,
+	}
+	if !defaults.updateValue(field, value, key, path) {
+		b.errors.add(FieldError{
+			Field: path,
+			Key:   b.qualify(key),
+			Value: defaultValue,
+			Type:  field.Type,
+			Err:   ErrInvalidDefault,
+		})
+		return false
+	}
+	return true
+}
+
+// reportField records where the field's value came from. The 'bound' flag indicates that the source
+// supplied the value while 'usedDefault' indicates that we used the default from the struct tag.
+// We mask the values of secret fields.
+func (b standardBinder) reportField(field reflect.StructField, value reflect.Value, key string, path string, bound bool, usedDefault bool, secret bool) {
+	report := FieldReport{
+		Field: path,
+		Key:   b.qualify(key),
+		Value: formatValue(value),
+	}
+	if secret {
+		report.Value = redactValue(report.Value)
+	}
+
+	switch {
+	case usedDefault:
+		report.Source = "default"
+		report.Default = true
+	case bound:
+		origin := b.explainBound(field, key, path)
+		report.Source = origin.Source
+		report.Default = origin.Default
+	}
+	b.report.add(report)
+}
+
+// explainBound reports the origin of the value that we bound to the field. A regular Chain skips
+// sources whose values can't be converted to the field's type, so we ask which of its sources would
+// have supplied the value rather than blaming the first one that has any value at all.
+func (b standardBinder) explainBound(field reflect.StructField, key string, path string) Origin {
+	if chain, ok := b.Source.(*chainSource); ok && !chain.strict {
+		for _, source := range chain.sources {
+			layer := b
+			layer.Source = source
+			layer.errors = &BindError{}
+			layer.report = nil
+			if layer.updateValue(field, reflect.New(field.Type).Elem(), key, path) {
+				return layer.explainBound(field, key, path)
+			}
+		}
+	}
+
+	origin := Explain(b.Source, key)
+	if !origin.Found && b.hasKeys(key) {
+		// Maps bound from prefixed keys (e.g. "LIMIT_TENANT_A") don't have a value for the key itself.
+		origin = Explain(b.Source, b.Source.(Enumerable).Keys(key + b.Source.Options().Namespace.delimiter())[0])
+	}
+	return origin
+}
+
+// updateValue populates the field's value using the source's value for the given key, returning
+// whether the source had a usable value for it.
+func (b standardBinder) updateValue(field reflect.StructField, value reflect.Value, key string, path string) bool {
+	// Types that know how to parse themselves (or that you registered a decoder for) take precedence
+	// over the kind-based logic below. Otherwise named string types would never hit UnmarshalText().
+	if decode := decoderFor(field.Type); decode != nil {
+		return b.updateDecoded(value, key, decode)
+	}
+
 	// There are a couple of common types we support that aren't built-ins, so check those first
 	switch field.Type {
 	case typeDuration:
-		if v, ok := b.Source.Duration(key); ok {
+		v, ok := b.Source.Duration(key)
+		if ok {
 			value.Set(reflect.ValueOf(v))
 		}
-		return
+		return ok
 	case typeTime:
-		if v, ok := b.Source.Time(key); ok {
+		v, ok := b.Source.Time(key)
+		if ok {
 			value.Set(reflect.ValueOf(v))
 		}
-		return
+		return ok
 	}
 
 	switch field.Type.Kind() {
 	case reflect.String:
-		if v, ok := b.Source.String(key); ok {
+		v, ok := b.Source.String(key)
+		if ok {
 			value.SetString(v)
 		}
+		return ok
 	case reflect.Bool:
-		if v, ok := b.Source.Bool(key); ok {
+		v, ok := b.Source.Bool(key)
+		if ok {
 			value.SetBool(v)
 		}
+		return ok
 	case reflect.Int:
-		if v, ok := b.Source.Int(key); ok {
+		v, ok := b.Source.Int(key)
+		if ok {
 			value.SetInt(int64(v))
 		}
+		return ok
 	case reflect.Int8:
-		if v, ok := b.Source.Int8(key); ok {
+		v, ok := b.Source.Int8(key)
+		if ok {
 			value.SetInt(int64(v))
 		}
+		return ok
 	case reflect.Int16:
-		if v, ok := b.Source.Int16(key); ok {
+		v, ok := b.Source.Int16(key)
+		if ok {
 			value.SetInt(int64(v))
 		}
+		return ok
 	case reflect.Int32:
-		if v, ok := b.Source.Int32(key); ok {
+		v, ok := b.Source.Int32(key)
+		if ok {
 			value.SetInt(int64(v))
 		}
+		return ok
 	case reflect.Int64:
-		if v, ok := b.Source.Int64(key); ok {
+		v, ok := b.Source.Int64(key)
+		if ok {
 			value.SetInt(v)
 		}
+		return ok
 	case reflect.Uint:
-		if v, ok := b.Source.Uint(key); ok {
+		v, ok := b.Source.Uint(key)
+		if ok {
 			value.SetUint(uint64(v))
 		}
+		return ok
 	case reflect.Uint8:
-		if v, ok := b.Source.Uint8(key); ok {
+		v, ok := b.Source.Uint8(key)
+		if ok {
 			value.SetUint(uint64(v))
 		}
+		return ok
 	case reflect.Uint16:
-		if v, ok := b.Source.Uint16(key); ok {
+		v, ok := b.Source.Uint16(key)
+		if ok {
 			value.SetUint(uint64(v))
 		}
+		return ok
 	case reflect.Uint32:
-		if v, ok := b.Source.Uint32(key); ok {
+		v, ok := b.Source.Uint32(key)
+		if ok {
 			value.SetUint(uint64(v))
 		}
+		return ok
 	case reflect.Uint64:
-		if v, ok := b.Source.Uint64(key); ok {
+		v, ok := b.Source.Uint64(key)
+		if ok {
 			value.SetUint(v)
 		}
+		return ok
 	case reflect.Float32:
-		if v, ok := b.Source.Float32(key); ok {
+		v, ok := b.Source.Float32(key)
+		if ok {
 			value.SetFloat(float64(v))
 		}
+		return ok
 	case reflect.Float64:
-		if v, ok := b.Source.Float64(key); ok {
+		v, ok := b.Source.Float64(key)
+		if ok {
 			value.SetFloat(v)
 		}
+		return ok
 	case reflect.Struct:
-		b.bindPrefix(value.Addr().Interface(), key)
+		return b.bindPrefix(value.Addr().Interface(), key, path)
 	case reflect.Slice:
-		b.updateSlice(field, value, key)
+		return b.updateSlice(field, value, key)
+	case reflect.Map:
+		return b.updateMap(field, value, key, path)
 	case reflect.Ptr:
-		b.updatePointer(field, value, key)
+		return b.updatePointer(field, value, key, path)
 	}
+	return false
 }
 
-func (b standardBinder) updatePointer(field reflect.StructField, value reflect.Value, key string) {
-	// There are a couple of common types we support that aren't built-ins, so check those first
-	switch field.Type.Elem() {
-	case typeDuration:
-		if v, ok := b.Source.Duration(key); ok {
-			value.Set(reflect.ValueOf(&v))
+// hasKeys indicates whether the source is Enumerable and has at least one key under the given prefix.
+func (b standardBinder) hasKeys(prefix string) bool {
+	enumerable, ok := b.Source.(Enumerable)
+	return ok && len(enumerable.Keys(prefix+b.Source.Options().Namespace.delimiter())) > 0
+}
+
+// updateDecoded populates the field by running the source's raw string value through the decoder.
+func (b standardBinder) updateDecoded(value reflect.Value, key string, decode func(string) (reflect.Value, error)) bool {
+	raw, ok := b.Source.String(key)
+	if !ok {
+		return false
+	}
+	decoded, err := decode(raw)
+	if err != nil {
+		return false
+	}
+	value.Set(decoded)
+	return true
+}
+
+// updatePointer populates pointer fields by binding a brand new value of the pointer's element type
+// exactly like we would a non-pointer field, so every type we support also works as a pointer (even
+// pointers to pointers). The field is only assigned when the source actually had a value for it,
+// which lets you distinguish between "unset" (nil) and a zero value.
+func (b standardBinder) updatePointer(field reflect.StructField, value reflect.Value, key string, path string) bool {
+	elementType := field.Type.Elem()
+	if elementType.Kind() == reflect.Struct && elementType != typeTime {
+		if value.Pointer() != 0 {
+			return b.bindPrefix(value.Interface(), key, path)
 		}
-		return
-	case typeTime:
-		if v, ok := b.Source.Time(key); ok {
-			value.Set(reflect.ValueOf(&v))
+		return b.allocateStruct(elementType, value, key, path)
+	}
+
+	element := reflect.New(elementType)
+	if !value.IsNil() {
+		// Pointers to pointers to structs should still bind onto the struct you already supplied.
+		element.Elem().Set(value.Elem())
+	}
+	elementField := field
+	elementField.Type = elementType
+	if !b.updateValue(elementField, element.Elem(), key, path) {
+		return false
+	}
+	value.Set(element)
+	return true
+}
+
+// allocateStruct handles nil struct pointers such as an optional `TLS *TLSConfig`. We bind a brand
+// new instance of the struct and only assign it to the field if the source had at least one value
+// under the struct's prefix (even an invalid one). Otherwise, the pointer stays nil and we throw away
+// any required/validation errors for a sub-config that you never supplied in the first place.
+//
+// Self-referencing types (e.g. a linked list) would otherwise have us allocating forever. When the
+// source is Enumerable, we know exactly which prefixes have keys. Otherwise, we only go another level
+// deeper into the same type when the source has a value for one of that level's own fields, so
+// sources that can't list their keys stop at the first nested level that has no values of its own.
+func (b standardBinder) allocateStruct(structType reflect.Type, value reflect.Value, key string, path string) bool {
+	if b.isAllocating(structType) && !b.hasKeys(key) {
+		if b.shallow || !b.hasOwnValues(structType, key, path) {
+			return false
 		}
-		return
 	}
 
-	switch field.Type.Elem().Kind() {
-	case reflect.String:
-		if v, ok := b.Source.String(key); ok {
-			value.Set(reflect.ValueOf(&v))
+	probe := b
+	probe.errors = &BindError{}
+	if b.report != nil {
+		probe.report = &Report{}
+	}
+	probe.allocating = append(b.allocating[:len(b.allocating):len(b.allocating)], structType)
+
+	instance := reflect.New(structType)
+	found := probe.bindPrefix(instance.Interface(), key, path)
+	if !found && !errors.Is(probe.errors, ErrInvalidValue) {
+		return false
+	}
+
+	value.Set(instance)
+	b.errors.Fields = append(b.errors.Fields, probe.errors.Fields...)
+	if b.report != nil {
+		*b.report = append(*b.report, *probe.report...)
+	}
+	return found
+}
+
+// isAllocating indicates whether we're already in the middle of allocating a pointer to this type.
+func (b standardBinder) isAllocating(structType reflect.Type) bool {
+	for _, allocating := range b.allocating {
+		if allocating == structType {
+			return true
 		}
-	case reflect.Bool:
-		if v, ok := b.Source.Bool(key); ok {
-			value.Set(reflect.ValueOf(&v))
+	}
+	return false
+}
+
+// hasOwnValues binds a throwaway instance of the struct without allocating any more self-referencing
+// pointers inside of it, indicating whether the source has a value (even an invalid one) for any of
+// the struct's own fields.
+func (b standardBinder) hasOwnValues(structType reflect.Type, key string, path string) bool {
+	probe := b
+	probe.errors = &BindError{}
+	probe.report = nil
+	probe.shallow = true
+	found := probe.bindPrefix(reflect.New(structType).Interface(), key, path)
+	return found || errors.Is(probe.errors, ErrInvalidValue)
+}
+
+// updateSlice populates a slice field using the source's comma-separated value for the key. Each
+// element is parsed exactly like a standalone field of the slice's element type would be, so you can
+// have slices of any scalar type we support (ints, durations, TextUnmarshaler types, pointers, etc).
+func (b standardBinder) updateSlice(field reflect.StructField, value reflect.Value, key string) bool {
+	switch field.Type {
+	case typeStringSlice:
+		v, ok := b.Source.StringSlice(key)
+		if ok {
+			value.Set(reflect.ValueOf(v))
 		}
-	case reflect.Int:
-		if v, ok := b.Source.Int(key); ok {
-			value.Set(reflect.ValueOf(&v))
+		return ok
+	case typeByteSlice:
+		// Byte slices are more likely to be raw data than a list of comma-separated numbers.
+		v, ok := b.Source.String(key)
+		if ok {
+			value.SetBytes([]byte(v))
 		}
-	case reflect.Uint:
-		if v, ok := b.Source.Uint(key); ok {
-			value.Set(reflect.ValueOf(&v))
+		return ok
+	}
+
+	elementType := field.Type.Elem()
+	if !isScalar(elementType) {
+		return false
+	}
+	elements, ok := b.Source.StringSlice(key)
+	if !ok {
+		return false
+	}
+
+	slice := reflect.MakeSlice(field.Type, len(elements), len(elements))
+	for i, element := range elements {
+		if !b.updateElement(elementType, slice.Index(i), key, element) {
+			return false
 		}
-	case reflect.Float32:
-		if v, ok := b.Source.Float32(key); ok {
-			value.Set(reflect.ValueOf(&v))
+	}
+	value.Set(slice)
+	return true
+}
+
+// updateMap populates a map[string]T field. When the source has a value for the field's key, we parse
+// it as comma-separated "key=value" pairs (e.g. "team=core,tier=gold"). Otherwise, if the source can
+// enumerate its keys, we collect every key under the field's prefix. For instance, a field w/ the key
+// "LIMIT" is populated by "LIMIT_TENANT_A" and "LIMIT_TENANT_B" using the keys "TENANT_A" and "TENANT_B".
+func (b standardBinder) updateMap(field reflect.StructField, value reflect.Value, key string, path string) bool {
+	keyType := field.Type.Key()
+	elementType := field.Type.Elem()
+	if keyType.Kind() != reflect.String || !isScalar(elementType) {
+		return false
+	}
+
+	if raw, ok := b.Source.String(key); ok {
+		pairs, ok := Massage{}.StringToMap(raw)
+		if !ok {
+			return false
 		}
-	case reflect.Float64:
-		if v, ok := b.Source.Float64(key); ok {
-			value.Set(reflect.ValueOf(&v))
+		result := reflect.MakeMapWithSize(field.Type, len(pairs))
+		for name, raw := range pairs {
+			element := reflect.New(elementType).Elem()
+			if !b.updateElement(elementType, element, key, raw) {
+				return false
+			}
+			result.SetMapIndex(reflect.ValueOf(name).Convert(keyType), element)
 		}
-	case reflect.Struct:
-		// Currently, we only support recursion into struct pointers if your input already
-		// has a non-nil value for it. I'm not 100% sure on the semantics of how this should
-		// work. If you come in null but have no values in your source to fill it, should we
-		// leave the pointer nil? If so, how should we detect if anything down the chain was
-		// supplied? Or should we supply a non-nil value for the struct with all default
-		// values for its fields? I don't have a strong pull in either direction, so we can
-		// leave this as a future enhancement.
-		if value.Pointer() != 0 {
-			b.bindPrefix(value.Interface(), key)
+		value.Set(result)
+		return true
+	}
+
+	enumerable, ok := b.Source.(Enumerable)
+	if !ok {
+		return false
+	}
+	prefix := key + b.Source.Options().Namespace.delimiter()
+	keys := enumerable.Keys(prefix)
+	if len(keys) == 0 {
+		return false
+	}
+
+	result := reflect.MakeMapWithSize(field.Type, len(keys))
+	for _, elementKey := range keys {
+		element := reflect.New(elementType).Elem()
+		elementField := reflect.StructField{Name: field.Name, Type: elementType}
+		if !b.updateValue(elementField, element, elementKey, path) {
+			// Report the specific key that was bad since the field's own key doesn't have a value.
+			b.checkInvalid(elementType, elementKey, path)
+			return false
 		}
+		name := strings.TrimPrefix(elementKey, prefix)
+		result.SetMapIndex(reflect.ValueOf(name).Convert(keyType), element)
 	}
+	value.Set(result)
+	return true
 }
 
-func (b standardBinder) updateSlice(field reflect.StructField, value reflect.Value, key string) {
-	// Determine what this is a slice of and invoke the appropriate slice getter on the source.
-	switch field.Type.Elem().Kind() {
-	case reflect.String:
-		if v, ok := b.Source.StringSlice(key); ok {
-			value.Set(reflect.ValueOf(v))
-		}
+// updateElement parses a single raw value from a slice/map as the given type, populating 'value'. We
+// feed the raw value through a source of its own so that it's parsed using the same rules as any
+// other value for a field of that type.
+func (b standardBinder) updateElement(elementType reflect.Type, value reflect.Value, key string, raw string) bool {
+	element := standardBinder{
+		Source: textSource(b.Source.Options(), raw),
+		errors: &BindError{},
 	}
+	field := reflect.StructField{Name: key, Type: elementType}
+	return element.updateValue(field, value, key, "")
+}
+
+// isNestedStruct indicates whether values of this type (or the type it points to) are structs whose
+// fields we bind individually rather than a single value.
+func isNestedStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isScalar(t)
+}
+
+// isScalar indicates whether values of this type are represented by a single raw value in a source
+// as opposed to things like structs which are made up of many keys.
+func isScalar(t reflect.Type) bool {
+	if decoderFor(t) != nil {
+		return true
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t == typeTime || decoderFor(t) != nil
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	}
+	return true
 }
 
 // resolveName looks at a struct field/attribute and determines the config key we should use to
 // try and look up its value. We'll first attempt to locate the 'conf' tag in case you defined
 // a specific name. Otherwise, we'll just use the upper-snake-cased version of the attribute name.
 func (b standardBinder) resolveName(field reflect.StructField) string {
-	if name := field.Tag.Get("conf"); name != "" {
-		return name
+	if name, _, _ := strings.Cut(field.Tag.Get("conf"), ","); strings.TrimSpace(name) != "" {
+		return strings.TrimSpace(name)
 	}
 
 	// Convert camel-cased field names to upper snake case by default.
@@ -214,5 +626,61 @@ func (b standardBinder) resolveName(field reflect.StructField) string {
 	return strings.ToUpper(snake)
 }
 
+// fieldOptions are the comma-separated settings that can follow the key name in a field's 'conf'
+// tag. For instance `conf:"DATABASE_URL,required"` or `conf:",required"` if you're happy with the
+// default key name for the field.
+type fieldOptions struct {
+	// required indicates that binding should fail if the source does not have a value for the field.
+	required bool
+	// hasDefault indicates that the field defined a default value (even if it's an empty string).
+	hasDefault bool
+	// defaultValue is the raw value we'll parse and use when the source doesn't have a value.
+	defaultValue string
+	// secret indicates that the field's value should never appear in errors or reports.
+	secret bool
+}
+
+// resolveOptions parses the settings that follow the key name in the field's 'conf' tag. You can
+// define a default value either using a separate tag (`default:"30s"`) or with a "default=" setting
+// in the 'conf' tag (`conf:"TIMEOUT,default=30s"`). Since default values for slices contain commas,
+// the "default=" setting must be the last one in the 'conf' tag; it consumes the rest of the tag.
+// The "secret" setting (`conf:"DB_PASSWORD,secret"`) masks the field's value in errors and reports.
+func (b standardBinder) resolveOptions(field reflect.StructField) fieldOptions {
+	options := fieldOptions{}
+	_, settings, _ := strings.Cut(field.Tag.Get("conf"), ",")
+	for settings != "" {
+		var setting string
+		setting, settings, _ = strings.Cut(settings, ",")
+
+		switch setting = strings.TrimSpace(setting); {
+		case setting == "required":
+			options.required = true
+		case setting == "secret":
+			options.secret = true
+		case strings.HasPrefix(setting, "default="):
+			options.hasDefault = true
+			options.defaultValue = strings.TrimPrefix(setting, "default=")
+			if settings != "" {
+				options.defaultValue += "," + settings
+			}
+			settings = ""
+		}
+	}
+
+	if defaultValue, ok := field.Tag.Lookup("default"); ok {
+		options.hasDefault = true
+		options.defaultValue = defaultValue
+	}
+	return options
+}
+
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
 var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
+
+// joinPath builds the Go-style path to a (possibly nested) field, such as "HTTP.TLS.Enabled".
+func joinPath(path string, fieldName string) string {
+	if path == "" {
+		return fieldName
+	}
+	return path + "." + fieldName
+}
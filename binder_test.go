@@ -1,7 +1,16 @@
 package configify_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -415,7 +424,8 @@ func (suite BinderSuite) TestModelBinder_OverrideEverything() {
 		source.On("Int", "NESTED_POINTER_INNER_INT").Return(152, true)
 		source.On("Uint", "NESTED_POINTER_INNER_UINT").Return(uint(162), true)
 
-		source.On("String", "NESTED_POINTER_NIL_INNER_STRING").Return("SHOULD STILL BE NIL", true)
+		// The nil pointer gets allocated because there's at least one value under its prefix.
+		source.On("String", "NESTED_POINTER_NIL_INNER_STRING").Return("New-NestedPointerNilInnerA", true)
 	})
 	configify.NewBinder(source).Bind(&input)
 
@@ -482,7 +492,9 @@ func (suite BinderSuite) TestModelBinder_OverrideEverything() {
 	suite.Equal(151, input.NestedRenamed.InnerInt)
 	suite.Equal(uint(161), input.NestedRenamed.InnerUint)
 
-	suite.Nil(input.NestedPointerNil)
+	suite.Require().NotNil(input.NestedPointerNil)
+	suite.Equal("New-NestedPointerNilInnerA", input.NestedPointerNil.InnerString)
+	suite.Equal(0, input.NestedPointerNil.InnerInt)
 
 	suite.Require().NotNil(input.NestedPointer)
 	suite.Equal("New-NestedPointerInnerA", input.NestedPointer.InnerString)
@@ -492,6 +504,639 @@ func (suite BinderSuite) TestModelBinder_OverrideEverything() {
 	suite.Equal(uint(162), input.NestedPointer.InnerUint)
 }
 
+// TestModelBinder_BindE ensures that we report all of the values that exist in the source but
+// can't be converted to their field's type while still binding everything else.
+func (suite BinderSuite) TestModelBinder_BindE() {
+	_ = os.Setenv("BINDE_STRING", "hello")
+	_ = os.Setenv("BINDE_INT", "abc")
+	_ = os.Setenv("BINDE_UINT", "-5")
+	_ = os.Setenv("BINDE_BOOL", "true")
+	_ = os.Setenv("BINDE_DURATION", "5 minutes")
+	_ = os.Setenv("BINDE_NESTED_INNER_INT", "12")
+	_ = os.Setenv("BINDE_NESTED_INNER_UINT", "twelve")
+	_ = os.Setenv("BINDE_FLOAT64_POINTER", "3.x")
+
+	input := TestStruct{Int: 42}
+	binder := configify.NewBinder(configify.Environment(configify.Namespace("BINDE")))
+	err := binder.BindE(&input)
+
+	// The good values should still be bound; the bad ones left alone.
+	suite.Equal("hello", input.String)
+	suite.Equal(42, input.Int)
+	suite.Equal(true, input.Bool)
+	suite.Equal(12, input.Nested.InnerInt)
+	suite.Nil(input.Float64Pointer)
+
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Require().Len(bindErr.Fields, 5)
+	suite.Equal(configify.FieldError{
+		Field: "Int",
+		Key:   "BINDE_INT",
+		Value: "abc",
+		Type:  reflect.TypeOf(0),
+		Err:   configify.ErrInvalidValue,
+	}, bindErr.Fields[0])
+	suite.Equal("BINDE_UINT", bindErr.Fields[1].Key)
+	suite.Equal("BINDE_FLOAT64_POINTER", bindErr.Fields[2].Key)
+	suite.Equal("3.x", bindErr.Fields[2].Value)
+	suite.Equal(reflect.TypeOf(time.Duration(0)), bindErr.Fields[3].Type)
+	suite.Equal("Nested.InnerUint", bindErr.Fields[4].Field)
+	suite.Equal("BINDE_NESTED_INNER_UINT", bindErr.Fields[4].Key)
+
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	suite.Contains(err.Error(), "BINDE_INT (Int): unable to parse 'abc' as int")
+	suite.Contains(err.Error(), "BINDE_NESTED_INNER_UINT (Nested.InnerUint): unable to parse 'twelve' as uint")
+
+	// Everything is good, so there's nothing to report. Missing values aren't errors either.
+	input = TestStruct{}
+	suite.NoError(configify.NewBinder(configify.Map(configify.Values{"INT": 5})).BindE(&input))
+	suite.NoError(configify.NewBinder(nil).BindE(&input))
+}
+
+// TestModelBinder_Required ensures that we report every required field that's missing from the
+// source using the fully qualified key name.
+func (suite BinderSuite) TestModelBinder_Required() {
+	type RequiredStruct struct {
+		DatabaseURL string `conf:"DATABASE_URL,required"`
+		Port        int    `conf:",required"`
+		Timeout     int    `conf:"TIMEOUT , required "`
+		Optional    string
+		Nested      struct {
+			Token string `conf:"TOKEN,required"`
+		}
+	}
+
+	input := RequiredStruct{DatabaseURL: "initial values don't count"}
+	source := configify.Map(configify.Values{"PORT": "abc", "TIMEOUT": 30})
+	err := configify.NewBinder(source).BindE(&input)
+
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Equal([]string{"DATABASE_URL", "NESTED_TOKEN"}, bindErr.Missing())
+	suite.True(errors.Is(err, configify.ErrRequired))
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	suite.Equal(30, input.Timeout)
+	suite.Equal("configify: missing configuration: DATABASE_URL, NESTED_TOKEN; PORT (Port): unable to parse 'abc' as int", err.Error())
+
+	// The missing keys should include the source's namespace so you know exactly what to set.
+	_ = os.Setenv("REQUIRED_PORT", "8080")
+	_ = os.Setenv("REQUIRED_TIMEOUT", "30")
+	_ = os.Setenv("REQUIRED_NESTED_TOKEN", "")
+	err = configify.NewBinder(configify.Environment(configify.Namespace("REQUIRED"))).BindE(&input)
+	bindErr, ok = err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Equal([]string{"REQUIRED_DATABASE_URL"}, bindErr.Missing())
+	suite.Equal("DatabaseURL", bindErr.Fields[0].Field)
+	suite.Equal(reflect.TypeOf(""), bindErr.Fields[0].Type)
+
+	// Chains report the key qualified by the source you'd set it in, not the chain's own namespace.
+	chain := configify.Chain(
+		configify.Environment(configify.Namespace("REQUIRED")),
+		configify.Map(configify.Values{"TIMEOUT": 30}))
+	_ = os.Setenv("REQUIRED_PORT", "abc")
+	err = configify.NewBinder(chain).BindE(&input)
+	bindErr, ok = err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Equal([]string{"REQUIRED_DATABASE_URL"}, bindErr.Missing())
+	suite.Equal("REQUIRED_PORT", bindErr.Fields[1].Key)
+	_ = os.Setenv("REQUIRED_PORT", "8080")
+
+	// Empty values are still values. Required just means that it must be defined.
+	_ = os.Setenv("REQUIRED_DATABASE_URL", "postgres://localhost")
+	input = RequiredStruct{}
+	suite.NoError(configify.NewBinder(configify.Environment(configify.Namespace("REQUIRED"))).BindE(&input))
+	suite.Equal("postgres://localhost", input.DatabaseURL)
+	suite.Equal(8080, input.Port)
+
+	// Structs and maps whose own keys have invalid values aren't missing, nor do they use defaults.
+	type PortConfig struct {
+		Port int
+	}
+	type InvalidStruct struct {
+		TLS      *PortConfig    `conf:"TLS,required"`
+		HTTP     PortConfig     `conf:"HTTP,required"`
+		Limit    map[string]int `conf:"LIMIT,required"`
+		Defaults map[string]int `conf:"DEFAULTS,default=a=1"`
+	}
+	_ = os.Setenv("REQUIRED_INVALID_TLS_PORT", "abc")
+	_ = os.Setenv("REQUIRED_INVALID_HTTP_PORT", "abc")
+	_ = os.Setenv("REQUIRED_INVALID_LIMIT_TENANT_A", "abc")
+	_ = os.Setenv("REQUIRED_INVALID_DEFAULTS_TENANT_A", "abc")
+	invalidInput := InvalidStruct{}
+	err = configify.NewBinder(configify.Environment(configify.Namespace("REQUIRED_INVALID"))).BindE(&invalidInput)
+	bindErr, ok = err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Empty(bindErr.Missing())
+	suite.False(errors.Is(err, configify.ErrRequired))
+	suite.False(errors.Is(err, configify.ErrInvalidDefault))
+	suite.Require().Len(bindErr.Fields, 4)
+	suite.Equal("REQUIRED_INVALID_TLS_PORT", bindErr.Fields[0].Key)
+	suite.Equal("REQUIRED_INVALID_HTTP_PORT", bindErr.Fields[1].Key)
+	suite.Equal("REQUIRED_INVALID_LIMIT_TENANT_A", bindErr.Fields[2].Key)
+	suite.Equal("REQUIRED_INVALID_DEFAULTS_TENANT_A", bindErr.Fields[3].Key)
+	suite.Nil(invalidInput.Defaults)
+}
+
+// TestModelBinder_DefaultTags ensures that we fall back to the default values in struct tags when
+// the source doesn't have a value, parsing them just like we would a source value.
+func (suite BinderSuite) TestModelBinder_DefaultTags() {
+	type DefaultStruct struct {
+		Host       string        `default:"localhost"`
+		Port       uint16        `conf:"HTTP_PORT,default=8080"`
+		Timeout    time.Duration `default:"30s"`
+		Labels     []string      `conf:"LABELS,required,default=a, b,c"`
+		Debug      *bool         `default:"true"`
+		Empty      string        `conf:"EMPTY,default="`
+		Overridden int           `conf:"OVERRIDDEN,default=1" default:"2"`
+		Required   string        `conf:",required" default:"not actually missing"`
+		NoDefault  string
+		Nested     struct {
+			Start time.Time `default:"2019-12-25"`
+		}
+	}
+
+	input := DefaultStruct{Host: "example.com", Empty: "not empty", NoDefault: "untouched"}
+	err := configify.NewBinder(configify.Map(configify.Values{"TIMEOUT": time.Minute})).BindE(&input)
+	suite.NoError(err)
+
+	suite.Equal("localhost", input.Host)
+	suite.Equal(uint16(8080), input.Port)
+	suite.Equal(time.Minute, input.Timeout)
+	suite.Equal([]string{"a", "b", "c"}, input.Labels)
+	suite.Require().NotNil(input.Debug)
+	suite.Equal(true, *input.Debug)
+	suite.Equal("", input.Empty)
+	suite.Equal(2, input.Overridden)
+	suite.Equal("not actually missing", input.Required)
+	suite.Equal("untouched", input.NoDefault)
+	suite.Equal(time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC), input.Nested.Start)
+
+	// Garbage defaults are reported just like garbage source values.
+	type BadDefaultStruct struct {
+		Timeout time.Duration `default:"thirty seconds"`
+	}
+	badInput := BadDefaultStruct{}
+	err = configify.NewBinder(configify.Environment(configify.Namespace("BAD"))).BindE(&badInput)
+	suite.Require().Error(err)
+	suite.True(errors.Is(err, configify.ErrInvalidDefault))
+	suite.Equal("configify: BAD_TIMEOUT (Timeout): unable to parse default value 'thirty seconds' as time.Duration", err.Error())
+}
+
+// logLevel is a custom enum that knows how to parse itself from text.
+type logLevel int
+
+func (l *logLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	case "warn":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown log level '%s'", text)
+	}
+	return nil
+}
+
+// jsonPair only knows how to unmarshal itself from JSON.
+type jsonPair struct {
+	Left  string
+	Right string
+}
+
+func (p *jsonPair) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	left, right, ok := strings.Cut(raw, ":")
+	if !ok {
+		return fmt.Errorf("invalid pair '%s'", raw)
+	}
+	p.Left, p.Right = left, right
+	return nil
+}
+
+// temperature is registered w/ a custom decoder, so it should ignore the fact that it's a float.
+type temperature float64
+
+func init() {
+	configify.RegisterDecoder(reflect.TypeOf(temperature(0)), func(value string) (interface{}, error) {
+		degrees, err := strconv.ParseFloat(strings.TrimSuffix(value, "F"), 64)
+		return temperature((degrees - 32) * 5 / 9), err
+	})
+}
+
+// TestModelBinder_Decoders ensures that we can bind types that implement encoding.TextUnmarshaler or
+// json.Unmarshaler as well as types that have a decoder registered via RegisterDecoder().
+func (suite BinderSuite) TestModelBinder_Decoders() {
+	type DecoderStruct struct {
+		IP           net.IP
+		Level        logLevel
+		LevelPointer *logLevel
+		Pattern      *regexp.Regexp
+		Pair         jsonPair
+		URL          url.URL
+		URLPointer   *url.URL
+		Temperature  temperature
+		Missing      *logLevel
+		Timeout      *time.Duration
+	}
+
+	_ = os.Setenv("DECODE_IP", "10.0.0.1")
+	_ = os.Setenv("DECODE_LEVEL", "WARN")
+	_ = os.Setenv("DECODE_LEVEL_POINTER", "info")
+	_ = os.Setenv("DECODE_PATTERN", "^[a-z]+$")
+	_ = os.Setenv("DECODE_PAIR", "foo:bar")
+	_ = os.Setenv("DECODE_URL", "https://example.com/path?q=1")
+	_ = os.Setenv("DECODE_URL_POINTER", "postgres://localhost:5432/db")
+	_ = os.Setenv("DECODE_TEMPERATURE", "212F")
+	_ = os.Setenv("DECODE_TIMEOUT", "5m")
+
+	input := DecoderStruct{}
+	err := configify.NewBinder(configify.Environment(configify.Namespace("DECODE"))).BindE(&input)
+	suite.Require().NoError(err)
+
+	suite.Equal(net.ParseIP("10.0.0.1"), input.IP)
+	suite.Equal(logLevel(2), input.Level)
+	suite.Require().NotNil(input.LevelPointer)
+	suite.Equal(logLevel(1), *input.LevelPointer)
+	suite.Require().NotNil(input.Pattern)
+	suite.True(input.Pattern.MatchString("hello"))
+	suite.Equal(jsonPair{Left: "foo", Right: "bar"}, input.Pair)
+	suite.Equal("example.com", input.URL.Host)
+	suite.Equal("1", input.URL.Query().Get("q"))
+	suite.Require().NotNil(input.URLPointer)
+	suite.Equal("postgres", input.URLPointer.Scheme)
+	suite.Equal(temperature(100), input.Temperature)
+	suite.Nil(input.Missing)
+	suite.Require().NotNil(input.Timeout)
+	suite.Equal(5*time.Minute, *input.Timeout)
+
+	// Values that the decoders reject are reported like any other unparsable value.
+	_ = os.Setenv("DECODE_IP", "not an ip")
+	_ = os.Setenv("DECODE_LEVEL", "trace")
+	_ = os.Setenv("DECODE_URL", "://nope")
+	_ = os.Setenv("DECODE_TEMPERATURE", "hot")
+
+	input = DecoderStruct{}
+	err = configify.NewBinder(configify.Environment(configify.Namespace("DECODE"))).BindE(&input)
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+
+	var keys []string
+	for _, field := range bindErr.Fields {
+		keys = append(keys, field.Key)
+	}
+	suite.Equal([]string{"DECODE_IP", "DECODE_LEVEL", "DECODE_URL", "DECODE_TEMPERATURE"}, keys)
+	suite.Nil(input.IP)
+	suite.Equal("foo", input.Pair.Left)
+}
+
+// TestModelBinder_Slices ensures that we can bind slices of any scalar type from comma-separated values.
+func (suite BinderSuite) TestModelBinder_Slices() {
+	type SliceStruct struct {
+		Ints      []int
+		Int8s     []int8
+		Uints     []uint64
+		Floats    []float64
+		Bools     []bool
+		Durations []time.Duration
+		Times     []time.Time
+		Levels    []logLevel
+		IPs       []net.IP `conf:"IPS"`
+		Pointers  []*int
+		Named     []logName
+		Bytes     []byte
+		Empty     []int
+		Invalid   []int
+		Missing   []int
+		Structs   []Nested
+	}
+
+	_ = os.Setenv("SLICES_INTS", "1, 2,3")
+	_ = os.Setenv("SLICES_INT8S", "-8,8")
+	_ = os.Setenv("SLICES_UINTS", "10,20")
+	_ = os.Setenv("SLICES_FLOATS", "1.5, -2.25")
+	_ = os.Setenv("SLICES_BOOLS", "true,FALSE")
+	_ = os.Setenv("SLICES_DURATIONS", "5s,1m30s")
+	_ = os.Setenv("SLICES_TIMES", "2019-12-25,2020-01-01T12:00:00Z")
+	_ = os.Setenv("SLICES_LEVELS", "debug,warn")
+	_ = os.Setenv("SLICES_IPS", "10.0.0.1,::1")
+	_ = os.Setenv("SLICES_POINTERS", "4,5")
+	_ = os.Setenv("SLICES_NAMED", "foo,bar")
+	_ = os.Setenv("SLICES_BYTES", "raw,data")
+	_ = os.Setenv("SLICES_EMPTY", "")
+	_ = os.Setenv("SLICES_INVALID", "1,two,3")
+	_ = os.Setenv("SLICES_STRUCTS", "ignored")
+
+	input := SliceStruct{Invalid: []int{42}}
+	err := configify.NewBinder(configify.Environment(configify.Namespace("SLICES"))).BindE(&input)
+
+	suite.Equal([]int{1, 2, 3}, input.Ints)
+	suite.Equal([]int8{-8, 8}, input.Int8s)
+	suite.Equal([]uint64{10, 20}, input.Uints)
+	suite.Equal([]float64{1.5, -2.25}, input.Floats)
+	suite.Equal([]bool{true, false}, input.Bools)
+	suite.Equal([]time.Duration{5 * time.Second, 90 * time.Second}, input.Durations)
+	suite.Equal([]time.Time{
+		time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, input.Times)
+	suite.Equal([]logLevel{0, 2}, input.Levels)
+	suite.Equal([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")}, input.IPs)
+	suite.Require().Len(input.Pointers, 2)
+	suite.Equal(4, *input.Pointers[0])
+	suite.Equal(5, *input.Pointers[1])
+	suite.Equal([]logName{"foo", "bar"}, input.Named)
+	suite.Equal([]byte("raw,data"), input.Bytes)
+	suite.Equal([]int{}, input.Empty)
+	suite.Equal([]int{42}, input.Invalid)
+	suite.Nil(input.Missing)
+	suite.Nil(input.Structs)
+
+	// If any element is garbage, we leave the whole slice alone and report it. Slices of types that
+	// can't be represented by a single value can never be bound from one, so we report those, too.
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Require().Len(bindErr.Fields, 2)
+	suite.Equal("SLICES_INVALID", bindErr.Fields[0].Key)
+	suite.Equal("1,two,3", bindErr.Fields[0].Value)
+	suite.Equal("SLICES_STRUCTS", bindErr.Fields[1].Key)
+}
+
+// TestModelBinder_AllocatePointers ensures that nil struct pointers are only allocated when the source
+// has at least one value under the struct's prefix.
+func (suite BinderSuite) TestModelBinder_AllocatePointers() {
+	type TLSConfig struct {
+		CertFile string `conf:",required"`
+		Port     int    `default:"443" min:"1"`
+	}
+	type Node struct {
+		Name string
+		Next *Node
+	}
+	type AllocateStruct struct {
+		TLS     *TLSConfig
+		Backup  *TLSConfig
+		Invalid *TLSConfig
+		Nested  struct {
+			Deep *TLSConfig
+		}
+		List *Node
+	}
+
+	_ = os.Setenv("ALLOCATE_TLS_CERT_FILE", "/etc/cert.pem")
+	_ = os.Setenv("ALLOCATE_INVALID_PORT", "abc")
+	_ = os.Setenv("ALLOCATE_NESTED_DEEP_PORT", "8443")
+	_ = os.Setenv("ALLOCATE_LIST_NAME", "first")
+	_ = os.Setenv("ALLOCATE_LIST_NEXT_NAME", "second")
+
+	input := AllocateStruct{}
+	err := configify.NewBinder(configify.Environment(configify.Namespace("ALLOCATE"))).BindE(&input)
+
+	suite.Require().NotNil(input.TLS)
+	suite.Equal("/etc/cert.pem", input.TLS.CertFile)
+	suite.Equal(443, input.TLS.Port)
+
+	// Nothing under BACKUP, so we don't complain about its required fields, either.
+	suite.Nil(input.Backup)
+
+	suite.Require().NotNil(input.Nested.Deep)
+	suite.Equal(8443, input.Nested.Deep.Port)
+
+	// Self-referencing types only go as deep as the source has keys for.
+	suite.Require().NotNil(input.List)
+	suite.Equal("first", input.List.Name)
+	suite.Require().NotNil(input.List.Next)
+	suite.Equal("second", input.List.Next.Name)
+	suite.Nil(input.List.Next.Next)
+
+	// Sources that can't enumerate their keys go as deep as there are values, but no deeper.
+	node := Node{}
+	source := configifytest.NewMockSource(func(source *configifytest.MockSource) {
+		source.On("String", "NEXT_NAME").Return("second", true)
+		source.On("String", "NEXT_NEXT_NAME").Return("third", true)
+		source.On("String", "NEXT_NEXT_NEXT_NAME").Return("fourth", true)
+	})
+	configify.NewBinder(source).Bind(&node)
+	suite.Require().NotNil(node.Next)
+	suite.Equal("second", node.Next.Name)
+	suite.Require().NotNil(node.Next.Next)
+	suite.Equal("third", node.Next.Next.Name)
+	suite.Require().NotNil(node.Next.Next.Next)
+	suite.Equal("fourth", node.Next.Next.Next.Name)
+	suite.Nil(node.Next.Next.Next.Next)
+
+	// Without being able to list keys, we can't see past a nested level that has no values of its
+	// own. Enumerable sources like the environment don't have this limitation.
+	node = Node{}
+	source = configifytest.NewMockSource(func(source *configifytest.MockSource) {
+		source.On("String", "NEXT_NAME").Return("second", true)
+		source.On("String", "NEXT_NEXT_NEXT_NAME").Return("fourth", true)
+	})
+	configify.NewBinder(source).Bind(&node)
+	suite.Require().NotNil(node.Next)
+	suite.Nil(node.Next.Next)
+
+	_ = os.Setenv("ALLOCATE_GAP_NEXT_NEXT_NAME", "third")
+	defer os.Unsetenv("ALLOCATE_GAP_NEXT_NEXT_NAME")
+	node = Node{}
+	configify.NewBinder(configify.Environment(configify.Namespace("ALLOCATE_GAP"))).Bind(&node)
+	suite.Require().NotNil(node.Next)
+	suite.Require().NotNil(node.Next.Next)
+	suite.Equal("third", node.Next.Next.Name)
+
+	// Invalid values still count as being present so that we can report them.
+	suite.Require().NotNil(input.Invalid)
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Equal([]string{"ALLOCATE_INVALID_CERT_FILE", "ALLOCATE_NESTED_DEEP_CERT_FILE"}, bindErr.Missing())
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	suite.Len(bindErr.Fields, 3)
+}
+
+// TestModelBinder_Pointers ensures that every type we can bind as a value can also be bound as a pointer.
+func (suite BinderSuite) TestModelBinder_Pointers() {
+	type PointerStruct struct {
+		Int8        *int8
+		Int16       *int16
+		Int32       *int32
+		Int64       *int64
+		Uint8       *uint8
+		Uint16      *uint16
+		Uint32      *uint32
+		Uint64      *uint64
+		Strings     *[]string
+		Ints        *[]int
+		Labels      *map[string]string
+		Named       *logName
+		IntPointer  **int
+		Nested      **Nested
+		NestedEmpty **Nested
+		Missing     *int64
+		Invalid     *int16
+	}
+
+	_ = os.Setenv("POINTERS_INT8", "-8")
+	_ = os.Setenv("POINTERS_INT16", "16")
+	_ = os.Setenv("POINTERS_INT32", "32")
+	_ = os.Setenv("POINTERS_INT64", "64")
+	_ = os.Setenv("POINTERS_UINT8", "8")
+	_ = os.Setenv("POINTERS_UINT16", "16")
+	_ = os.Setenv("POINTERS_UINT32", "32")
+	_ = os.Setenv("POINTERS_UINT64", "64")
+	_ = os.Setenv("POINTERS_STRINGS", "a,b")
+	_ = os.Setenv("POINTERS_INTS", "1,2")
+	_ = os.Setenv("POINTERS_LABELS", "a=b")
+	_ = os.Setenv("POINTERS_NAMED", "foo")
+	_ = os.Setenv("POINTERS_INT_POINTER", "0")
+	_ = os.Setenv("POINTERS_NESTED_INNER_INT", "5")
+	_ = os.Setenv("POINTERS_INVALID", "abc")
+
+	input := PointerStruct{}
+	err := configify.NewBinder(configify.Environment(configify.Namespace("POINTERS"))).BindE(&input)
+
+	suite.Require().NotNil(input.Int8)
+	suite.Equal(int8(-8), *input.Int8)
+	suite.Require().NotNil(input.Int16)
+	suite.Equal(int16(16), *input.Int16)
+	suite.Require().NotNil(input.Int32)
+	suite.Equal(int32(32), *input.Int32)
+	suite.Require().NotNil(input.Int64)
+	suite.Equal(int64(64), *input.Int64)
+	suite.Require().NotNil(input.Uint8)
+	suite.Equal(uint8(8), *input.Uint8)
+	suite.Require().NotNil(input.Uint16)
+	suite.Equal(uint16(16), *input.Uint16)
+	suite.Require().NotNil(input.Uint32)
+	suite.Equal(uint32(32), *input.Uint32)
+	suite.Require().NotNil(input.Uint64)
+	suite.Equal(uint64(64), *input.Uint64)
+	suite.Require().NotNil(input.Strings)
+	suite.Equal([]string{"a", "b"}, *input.Strings)
+	suite.Require().NotNil(input.Ints)
+	suite.Equal([]int{1, 2}, *input.Ints)
+	suite.Require().NotNil(input.Labels)
+	suite.Equal(map[string]string{"a": "b"}, *input.Labels)
+	suite.Require().NotNil(input.Named)
+	suite.Equal(logName("foo"), *input.Named)
+
+	// Zero values are still values, so the pointers are set.
+	suite.Require().NotNil(input.IntPointer)
+	suite.Require().NotNil(*input.IntPointer)
+	suite.Equal(0, **input.IntPointer)
+
+	suite.Require().NotNil(input.Nested)
+	suite.Require().NotNil(*input.Nested)
+	suite.Equal(5, (*input.Nested).InnerInt)
+	suite.Nil(input.NestedEmpty)
+	suite.Nil(input.Missing)
+	suite.Nil(input.Invalid)
+
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Require().Len(bindErr.Fields, 1)
+	suite.Equal("POINTERS_INVALID", bindErr.Fields[0].Key)
+
+	// Pointers to pointers should bind onto the struct you already have rather than replacing it.
+	existing := &Nested{InnerString: "existing"}
+	input = PointerStruct{Nested: &existing}
+	configify.NewBinder(configify.Environment(configify.Namespace("POINTERS"))).Bind(&input)
+	suite.Equal("existing", existing.InnerString)
+	suite.Equal(5, existing.InnerInt)
+}
+
+// TestModelBinder_Maps ensures that we can bind maps using either inline "key=value" pairs or by
+// collecting every key under the field's prefix.
+func (suite BinderSuite) TestModelBinder_Maps() {
+	type MapStruct struct {
+		Labels    map[string]string
+		Limit     map[string]int
+		Timeouts  map[logName]time.Duration
+		Levels    map[string]logLevel
+		Empty     map[string]int
+		Invalid   map[string]int
+		BadPair   map[string]string
+		BadPrefix map[string]uint
+		Missing   map[string]int
+		Structs   map[string]Nested
+	}
+
+	_ = os.Setenv("MAPS_LABELS", "team=core, tier = gold,empty=")
+	_ = os.Setenv("MAPS_LIMIT_TENANT_A", "5")
+	_ = os.Setenv("MAPS_LIMIT_TENANT_B", "9")
+	_ = os.Setenv("MAPS_TIMEOUTS", "read=5s,write=1m")
+	_ = os.Setenv("MAPS_LEVELS_API", "warn")
+	_ = os.Setenv("MAPS_EMPTY", "")
+	_ = os.Setenv("MAPS_INVALID", "a=1,b=two")
+	_ = os.Setenv("MAPS_BAD_PAIR", "a=1,b")
+	_ = os.Setenv("MAPS_BAD_PREFIX_X", "1")
+	_ = os.Setenv("MAPS_BAD_PREFIX_Y", "-1")
+	_ = os.Setenv("MAPS_STRUCTS_FOO_INNER_INT", "1")
+
+	input := MapStruct{}
+	err := configify.NewBinder(configify.Environment(configify.Namespace("MAPS"))).BindE(&input)
+
+	suite.Equal(map[string]string{"team": "core", "tier": "gold", "empty": ""}, input.Labels)
+	suite.Equal(map[string]int{"TENANT_A": 5, "TENANT_B": 9}, input.Limit)
+	suite.Equal(map[logName]time.Duration{"read": 5 * time.Second, "write": time.Minute}, input.Timeouts)
+	suite.Equal(map[string]logLevel{"API": 2}, input.Levels)
+	suite.Equal(map[string]int{}, input.Empty)
+	suite.Nil(input.Invalid)
+	suite.Nil(input.BadPair)
+	suite.Nil(input.BadPrefix)
+	suite.Nil(input.Missing)
+	suite.Nil(input.Structs)
+
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	var keys []string
+	for _, field := range bindErr.Fields {
+		keys = append(keys, field.Key)
+	}
+	suite.Equal([]string{"MAPS_INVALID", "MAPS_BAD_PAIR", "MAPS_BAD_PREFIX_Y"}, keys)
+	suite.Equal("BadPrefix", bindErr.Fields[2].Field)
+	suite.Equal("-1", bindErr.Fields[2].Value)
+
+	// Sources that can't enumerate their keys only support the inline format.
+	input = MapStruct{}
+	source := configifytest.NewMockSource(func(source *configifytest.MockSource) {
+		source.On("String", "LABELS").Return("a=b", true)
+		source.On("Int", "LIMIT_TENANT_A").Return(5, true)
+	})
+	configify.NewBinder(source).Bind(&input)
+	suite.Equal(map[string]string{"a": "b"}, input.Labels)
+	suite.Nil(input.Limit)
+}
+
+// logName is a named string type with no special parsing behavior.
+type logName string
+
+func ExampleRegisterDecoder() {
+	type Color struct{ R, G, B uint8 }
+
+	configify.RegisterDecoder(reflect.TypeOf(Color{}), func(value string) (interface{}, error) {
+		color := Color{}
+		_, err := fmt.Sscanf(value, "#%02x%02x%02x", &color.R, &color.G, &color.B)
+		return color, err
+	})
+
+	config := struct {
+		Background Color
+	}{}
+	source := configify.Map(configify.Values{
+		"BACKGROUND": "#ff8000",
+	})
+	configify.NewBinder(source).Bind(&config)
+
+	fmt.Printf("R=%d G=%d B=%d\n", config.Background.R, config.Background.G, config.Background.B)
+	// Output: R=255 G=128 B=0
+}
+
 func ExampleNewBinder() {
 	// Source attribute names are by convention, so Host will use the string
 	// value for "MYAPP_HOST" and Port will use the int value for "MYAPP_PORT".
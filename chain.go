@@ -0,0 +1,179 @@
+package configify
+
+import (
+	"time"
+)
+
+// Chain creates a layered source that resolves each key by asking the given sources for it in
+// order, returning the first value that is found. This lets you define a priority order for your
+// values such as "flags > environment > .env file > Consul > hard-coded defaults" without nesting
+// sources inside each other's Defaults.
+//
+// Keep in mind that a source with its own Defaults will always find a value for the keys in those
+// defaults, so lower priority sources in the chain never get a chance to supply them. You're
+// usually better off placing a Map of defaults at the end of the chain instead.
+//
+// The chain also supports watching. Any source in the chain that is a SourceWatcher will have
+// its change notifications forwarded to your callback, which receives the chain itself so that
+// re-binding your config still honors the priority order.
+func Chain(sources ...Source) SourceWatcher {
+	return newChain(sources, false)
+}
+
+// StrictChain creates a layered source just like Chain, except that the highest priority source
+// with any value for a key always supplies it, even if that value can't be converted to the type
+// you asked for. With a regular Chain, "HTTP_PORT=abc" in the environment quietly falls through to
+// a default further down the chain; with a StrictChain, the lookup fails so that BindE reports the
+// bad value. We only ask each source whether it has the key once, before calling the real getter.
+func StrictChain(sources ...Source) SourceWatcher {
+	return newChain(sources, true)
+}
+
+func newChain(sources []Source, strict bool) SourceWatcher {
+	var nonNilSources []Source
+	for _, source := range sources {
+		if source != nil {
+			nonNilSources = append(nonNilSources, source)
+		}
+	}
+	return &chainSource{sources: nonNilSources, strict: strict}
+}
+
+type chainSource struct {
+	sources []Source
+	strict  bool
+}
+
+// Options only carries the namespace delimiter of the highest priority source. The chain does not
+// apply a namespace of its own; each source in the chain applies its own when resolving keys.
+func (c *chainSource) Options() Options {
+	if len(c.sources) == 0 {
+		return Options{}
+	}
+	return Options{
+		Namespace: namespace{Delimiter: c.sources[0].Options().Namespace.Delimiter},
+	}
+}
+
+// Watch registers the callback with every source in the chain that supports watching.
+func (c *chainSource) Watch(callback func(source Source)) {
+	for _, source := range c.sources {
+		if watcher, ok := source.(SourceWatcher); ok {
+			watcher.Watch(func(Source) { callback(c) })
+		}
+	}
+}
+
+// Keys returns the combined keys of every source in the chain that is Enumerable.
+func (c *chainSource) Keys(prefix string) []string {
+	var keys []string
+	for _, source := range c.sources {
+		if enumerable, ok := source.(Enumerable); ok {
+			keys = append(keys, enumerable.Keys(prefix)...)
+		}
+	}
+	return uniqueSorted(keys)
+}
+
+// Explain reports the origin of the value from the highest priority source that has one. A regular
+// Chain might skip that source when its value can't be converted to the type you look up; a
+// StrictChain never does. When none of them have a value, the key is the one qualified by the
+// highest priority source since that's where you'd set it to supply a value.
+func (c *chainSource) Explain(key string) Origin {
+	for _, source := range c.sources {
+		if origin := Explain(source, key); origin.Found {
+			return origin
+		}
+	}
+	if len(c.sources) == 0 {
+		return Origin{Key: key}
+	}
+	return Origin{Key: Explain(c.sources[0], key).Key}
+}
+
+func (c *chainSource) String(key string) (string, bool) {
+	return chainLookup(c, key, Source.String)
+}
+
+func (c *chainSource) StringSlice(key string) ([]string, bool) {
+	return chainLookup(c, key, Source.StringSlice)
+}
+
+func (c *chainSource) Int(key string) (int, bool) {
+	return chainLookup(c, key, Source.Int)
+}
+
+func (c *chainSource) Int8(key string) (int8, bool) {
+	return chainLookup(c, key, Source.Int8)
+}
+
+func (c *chainSource) Int16(key string) (int16, bool) {
+	return chainLookup(c, key, Source.Int16)
+}
+
+func (c *chainSource) Int32(key string) (int32, bool) {
+	return chainLookup(c, key, Source.Int32)
+}
+
+func (c *chainSource) Int64(key string) (int64, bool) {
+	return chainLookup(c, key, Source.Int64)
+}
+
+func (c *chainSource) Uint(key string) (uint, bool) {
+	return chainLookup(c, key, Source.Uint)
+}
+
+func (c *chainSource) Uint8(key string) (uint8, bool) {
+	return chainLookup(c, key, Source.Uint8)
+}
+
+func (c *chainSource) Uint16(key string) (uint16, bool) {
+	return chainLookup(c, key, Source.Uint16)
+}
+
+func (c *chainSource) Uint32(key string) (uint32, bool) {
+	return chainLookup(c, key, Source.Uint32)
+}
+
+func (c *chainSource) Uint64(key string) (uint64, bool) {
+	return chainLookup(c, key, Source.Uint64)
+}
+
+func (c *chainSource) Float32(key string) (float32, bool) {
+	return chainLookup(c, key, Source.Float32)
+}
+
+func (c *chainSource) Float64(key string) (float64, bool) {
+	return chainLookup(c, key, Source.Float64)
+}
+
+func (c *chainSource) Bool(key string) (bool, bool) {
+	return chainLookup(c, key, Source.Bool)
+}
+
+func (c *chainSource) Duration(key string) (time.Duration, bool) {
+	return chainLookup(c, key, Source.Duration)
+}
+
+func (c *chainSource) Time(key string) (time.Time, bool) {
+	return chainLookup(c, key, Source.Time)
+}
+
+// chainLookup invokes the getter on each source until one of them reports that it has the value.
+// A StrictChain stops at the first source that has any value for the key, even if the getter can't
+// convert it. When none of them do, you get the same sane default that the Empty source would give
+// you.
+func chainLookup[T any](c *chainSource, key string, getter func(Source, string) (T, bool)) (T, bool) {
+	for _, source := range c.sources {
+		if c.strict {
+			if Explain(source, key).Found {
+				return getter(source, key)
+			}
+			continue
+		}
+		if value, ok := getter(source, key); ok {
+			return value, true
+		}
+	}
+	return getter(emptySource{}, key)
+}
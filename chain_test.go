@@ -0,0 +1,211 @@
+package configify_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestChainSuite(t *testing.T) {
+	suite.Run(t, new(ChainSuite))
+}
+
+type ChainSuite struct {
+	configifytest.SourceSuite
+}
+
+func (suite *ChainSuite) SetupTest() {
+	suite.Source = configify.Chain(
+		configify.Map(configify.Values{
+			"STRING":   "high",
+			"INT":      1,
+			"DURATION": time.Second,
+		}),
+		nil,
+		configify.Map(configify.Values{
+			"STRING":       "low",
+			"STRING_LOW":   "low only",
+			"STRING_SLICE": []string{"a", "b"},
+			"INT":          2,
+			"INT_LOW":      3,
+			"UINT16":       uint16(16),
+			"BOOL":         true,
+			"TIME":         time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC),
+		}),
+	)
+}
+
+func (suite ChainSuite) TestOptions() {
+	source := configify.Chain(
+		configify.Environment(configify.Namespace("FOO"), configify.NamespaceDelim(".")),
+		configify.Environment(configify.Namespace("BAR")))
+
+	// The chain does not apply any namespace itself; each source does that.
+	suite.Equal("", source.Options().Namespace.Name)
+	suite.Equal(".", source.Options().Namespace.Delimiter)
+
+	suite.Equal(configify.Options{}, configify.Chain().Options())
+}
+
+func (suite ChainSuite) TestPriority() {
+	suite.ExpectString("STRING", "high", true)
+	suite.ExpectString("STRING_LOW", "low only", true)
+	suite.ExpectString("NOT_FOUND", "", false)
+
+	suite.ExpectInt("INT", 1, true)
+	suite.ExpectInt("INT_LOW", 3, true)
+	suite.ExpectInt("NOT_FOUND", 0, false)
+
+	suite.ExpectStringSlice("STRING_SLICE", []string{"a", "b"}, true)
+	suite.ExpectStringSlice("NOT_FOUND", []string{}, false)
+	suite.ExpectUint16("UINT16", uint16(16), true)
+	suite.ExpectBool("BOOL", true, true)
+	suite.ExpectDuration("DURATION", time.Second, true)
+	suite.ExpectTime("TIME", time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC), true)
+}
+
+func (suite ChainSuite) TestInvalidValues() {
+	// A regular chain falls through to the next source when a value can't be converted.
+	suite.Source = configify.Chain(
+		configify.Map(configify.Values{"INT": "abc", "DURATION": "forever"}),
+		configify.Map(configify.Values{"INT": 2, "DURATION": time.Second, "STRING": "low"}))
+	suite.ExpectInt("INT", 2, true)
+	suite.ExpectDuration("DURATION", time.Second, true)
+	suite.ExpectString("INT", "abc", true)
+	suite.ExpectString("STRING", "low", true)
+
+	// A strict chain uses the highest priority source with a value, even if that value is garbage.
+	suite.Source = configify.StrictChain(
+		configify.Map(configify.Values{"INT": "abc", "DURATION": "forever"}),
+		configify.Map(configify.Values{"INT": 2, "DURATION": time.Second, "STRING": "low"}))
+	suite.ExpectInt("INT", 0, false)
+	suite.ExpectDuration("DURATION", 0, false)
+	suite.ExpectString("INT", "abc", true)
+	suite.ExpectString("STRING", "low", true)
+	suite.ExpectStringSlice("NOT_FOUND", []string{}, false)
+
+	_ = os.Setenv("CHAIN_PORT", "abc")
+	defer os.Unsetenv("CHAIN_PORT")
+	env := configify.Environment(configify.Namespace("CHAIN"))
+	defaults := configify.Map(configify.Values{"PORT": 80})
+
+	config := struct{ Port int }{}
+	suite.NoError(configify.NewBinder(configify.Chain(env, defaults)).BindE(&config))
+	suite.Equal(80, config.Port)
+
+	chain := configify.StrictChain(env, defaults)
+	_, err := configify.IntE(chain, "PORT")
+	var parseErr *configify.ParseError
+	suite.Require().True(errors.As(err, &parseErr), "Should be a *ParseError")
+	suite.Equal("abc", parseErr.Value)
+	suite.Equal("CHAIN_PORT", parseErr.QualifiedKey)
+
+	config.Port = 0
+	err = configify.NewBinder(chain).BindE(&config)
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	suite.Equal(0, config.Port)
+}
+
+func (suite ChainSuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "Chain should be enumerable")
+	suite.Equal([]string{"STRING", "STRING_LOW", "STRING_SLICE"}, enumerable.Keys("STRING"))
+
+	// Sources that can't enumerate their keys are simply skipped.
+	source := configify.Chain(
+		configifytest.NewMockSource(func(source *configifytest.MockSource) {}),
+		configify.Map(configify.Values{"A": "a"}))
+	suite.Equal([]string{"A"}, source.(configify.Enumerable).Keys(""))
+	suite.Equal(map[string]string{"A": "a"}, configify.All(source))
+}
+
+func (suite ChainSuite) TestEmpty() {
+	suite.Source = configify.Chain()
+	suite.ExpectString("STRING", "", false)
+	suite.ExpectInt64("INT", int64(0), false)
+	suite.ExpectStringSlice("STRING_SLICE", []string{}, false)
+}
+
+func (suite ChainSuite) TestWatch() {
+	watcherA := &fakeWatcher{Source: configify.Map(configify.Values{"A": "a"})}
+	watcherB := &fakeWatcher{Source: configify.Map(configify.Values{"B": "b"})}
+	chain := configify.Chain(watcherA, configify.Empty(), watcherB)
+
+	var notified []configify.Source
+	chain.Watch(func(source configify.Source) {
+		notified = append(notified, source)
+	})
+	suite.Len(watcherA.callbacks, 1)
+	suite.Len(watcherB.callbacks, 1)
+
+	watcherB.fire()
+	watcherA.fire()
+	suite.Require().Len(notified, 2)
+
+	// We should always get the chain back, not the child that changed.
+	suite.Equal(chain, notified[0])
+	suite.Equal(chain, notified[1])
+	value, _ := notified[0].String("B")
+	suite.Equal("b", value)
+}
+
+// fakeWatcher is a SourceWatcher that lets the test decide when changes "happen".
+type fakeWatcher struct {
+	configify.Source
+	callbacks []func(configify.Source)
+}
+
+func (w *fakeWatcher) Watch(callback func(configify.Source)) {
+	w.callbacks = append(w.callbacks, callback)
+}
+
+func (w *fakeWatcher) fire() {
+	for _, callback := range w.callbacks {
+		callback(w)
+	}
+}
+
+func ExampleChain() {
+	// Values explicitly set in the first source win, then the second, and so on.
+	config := configify.Chain(
+		configify.Map(configify.Values{
+			"HOST": "example.com",
+		}),
+		configify.Map(configify.Values{
+			"HOST": "localhost",
+			"PORT": 8080,
+		}),
+	)
+
+	host, ok := config.String("HOST")
+	fmt.Printf("Host: [%s] (%v)\n", host, ok)
+
+	port, ok := config.Int("PORT")
+	fmt.Printf("Port: [%d] (%v)\n", port, ok)
+
+	// Output: Host: [example.com] (true)
+	// Port: [8080] (true)
+}
+
+func ExampleStrictChain() {
+	// The highest priority source with a value wins, even when that value is garbage.
+	config := configify.StrictChain(
+		configify.Map(configify.Values{
+			"PORT": "abc",
+		}),
+		configify.Map(configify.Values{
+			"PORT": 8080,
+		}),
+	)
+
+	_, err := configify.IntE(config, "PORT")
+	fmt.Println(err)
+
+	// Output: configify: PORT: unable to parse 'abc' as int
+}
@@ -0,0 +1,211 @@
+package configify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Consul creates a source backed by the key/value store in a Consul cluster. We load every key
+// under your namespace when the source is created, so individual lookups never hit the network.
+// Since Consul keys are typically path-like, the namespace delimiter defaults to "/", so the
+// namespace "myapp" and key "HTTP_PORT" will resolve the Consul key "myapp/HTTP_PORT".
+//
+// The following options are used to connect to Consul:
+//
+//   - Address: The base URL of the Consul HTTP API. Defaults to "http://localhost:8500".
+//   - Password: The ACL token sent in the "X-Consul-Token" header.
+//   - Username: When supplied, we send the Username/Password using HTTP basic auth instead
+//     of the token header (e.g. when Consul sits behind an authenticating proxy).
+//   - Context: Cancel this context to stop watching for updates.
+//   - RefreshInterval: The maximum amount of time each blocking query will wait for changes
+//     before Consul responds. Defaults to 5 minutes.
+//
+// The source also supports watching. The first time you call Watch(), we start long polling Consul
+// using blocking queries and fire your callbacks whenever a value under your namespace changes.
+func Consul(opts ...Option) (SourceWatcher, error) {
+	options := apply(opts, &Options{
+		Namespace:       namespace{Delimiter: "/"},
+		Context:         context.Background(),
+		Defaults:        emptySource{},
+		Address:         "http://localhost:8500",
+		RefreshInterval: 5 * time.Minute,
+	})
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+	if !strings.Contains(options.Address, "://") {
+		options.Address = "http://" + options.Address
+	}
+
+	source := &consulSource{client: &http.Client{}}
+	source.stringSource = stringSource{name: "consul", options: *options, massage: Massage{Lenient: options.Lenient}, lookup: source.lookup}
+
+	values, index, err := source.fetch(0)
+	if err != nil {
+		return nil, err
+	}
+	source.values = values
+	source.index = index
+	return source, nil
+}
+
+type consulSource struct {
+	stringSource
+	client    *http.Client
+	mutex     sync.RWMutex
+	values    map[string]string
+	index     uint64
+	callbacks []func(Source)
+	watchOnce sync.Once
+}
+
+// consulEntry is a single key/value pair as it's returned by Consul's KV HTTP API.
+type consulEntry struct {
+	Key   string
+	Value []byte
+}
+
+func (c *consulSource) lookup(key string) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	value, ok := c.values[c.options.Namespace.Qualify(key)]
+	return strings.TrimSpace(value), ok
+}
+
+func (c *consulSource) Keys(prefix string) []string {
+	c.mutex.RLock()
+	names := make([]string, 0, len(c.values))
+	for name := range c.values {
+		names = append(names, name)
+	}
+	c.mutex.RUnlock()
+
+	keys := unqualifiedKeys(c.options.Namespace, names, prefix)
+	return keysWithDefaults(keys, c.options.Defaults, prefix)
+}
+
+// Watch registers a callback that fires whenever the values under this source's namespace change. The
+// first call starts the background loop that performs blocking queries against Consul; it runs until
+// the Context in your options is cancelled.
+func (c *consulSource) Watch(callback func(source Source)) {
+	c.mutex.Lock()
+	c.callbacks = append(c.callbacks, callback)
+	c.mutex.Unlock()
+
+	c.watchOnce.Do(func() {
+		go c.watch()
+	})
+}
+
+func (c *consulSource) watch() {
+	ctx := c.options.Context
+	for ctx.Err() == nil {
+		c.mutex.RLock()
+		index := c.index
+		c.mutex.RUnlock()
+
+		values, newIndex, err := c.fetch(index)
+		if err != nil {
+			// Don't hammer Consul if it's down; give it a beat before we try again.
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		// Per Consul's docs, the index can go backwards (e.g. after a snapshot restore) and should never
+		// be zero. In either case, reset to the smallest valid index so that the next query returns right
+		// away with fresh data rather than blocking on (or busy looping over) a bogus index.
+		if newIndex < index || newIndex == 0 {
+			newIndex = 1
+		}
+
+		c.mutex.Lock()
+		changed := !equalStringMaps(c.values, values)
+		c.values = values
+		c.index = newIndex
+		callbacks := append([]func(Source){}, c.callbacks...)
+		c.mutex.Unlock()
+
+		if changed && ctx.Err() == nil {
+			for _, callback := range callbacks {
+				callback(c)
+			}
+		}
+	}
+}
+
+// fetch loads all of the key/value pairs under our namespace. When 'index' is non-zero, this
+// performs a blocking query that won't return until something changes after that index (or the
+// wait time elapses).
+func (c *consulSource) fetch(index uint64) (map[string]string, uint64, error) {
+	prefix := c.options.Namespace.Prefix()
+	query := url.Values{}
+	query.Set("recurse", "true")
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", fmt.Sprintf("%dms", c.options.RefreshInterval.Milliseconds()))
+	}
+	address := strings.TrimSuffix(c.options.Address, "/") + "/v1/kv/" + prefix + "?" + query.Encode()
+
+	request, err := http.NewRequestWithContext(c.options.Context, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch {
+	case c.options.Username != "":
+		request.SetBasicAuth(c.options.Username, c.options.Password)
+	case c.options.Password != "":
+		request.Header.Set("X-Consul-Token", c.options.Password)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+
+	newIndex, _ := strconv.ParseUint(response.Header.Get("X-Consul-Index"), 10, 64)
+	values := map[string]string{}
+	switch response.StatusCode {
+	case http.StatusOK:
+		var entries []consulEntry
+		if err = json.NewDecoder(response.Body).Decode(&entries); err != nil {
+			return nil, 0, fmt.Errorf("consul: invalid response: %w", err)
+		}
+		for _, entry := range entries {
+			// Keys ending in a slash are just "folders" in the Consul UI, not real values.
+			if !strings.HasSuffix(entry.Key, "/") {
+				values[entry.Key] = string(entry.Value)
+			}
+		}
+		return values, newIndex, nil
+	case http.StatusNotFound:
+		// Consul responds w/ a 404 when there are no keys under the prefix. That's not an error,
+		// it just means that you haven't written any values yet.
+		return values, newIndex, nil
+	default:
+		return nil, 0, fmt.Errorf("consul: unexpected response status: %s", response.Status)
+	}
+}
+
+func equalStringMaps(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
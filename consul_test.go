@@ -0,0 +1,274 @@
+package configify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConsulSuite(t *testing.T) {
+	suite.Run(t, new(ConsulSuite))
+}
+
+type ConsulSuite struct {
+	configifytest.SourceSuite
+	consul *fakeConsul
+	server *httptest.Server
+}
+
+func (suite *ConsulSuite) SetupTest() {
+	suite.consul = newFakeConsul(map[string]string{
+		"myapp/":          "",
+		"myapp/STRING":    " foo ",
+		"myapp/INT":       "5",
+		"myapp/UINT16":    "160",
+		"myapp/BOOL":      "true",
+		"myapp/DURATION":  "5m",
+		"myapp/HTTP/PORT": "8080",
+		"other/STRING":    "other",
+	})
+	suite.server = httptest.NewServer(suite.consul)
+
+	source, err := configify.Consul(
+		configify.Address(suite.server.URL),
+		configify.Namespace("myapp"))
+	suite.Require().NoError(err)
+	suite.Source = source
+}
+
+func (suite *ConsulSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite ConsulSuite) TestOptions() {
+	options := suite.Source.Options()
+	suite.Equal("myapp", options.Namespace.Name)
+	suite.Equal("/", options.Namespace.Delimiter)
+	suite.Equal(suite.server.URL, options.Address)
+}
+
+func (suite ConsulSuite) TestLookup() {
+	suite.ExpectString("NOT_FOUND", "", false)
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectUint16("UINT16", uint16(160), true)
+	suite.ExpectBool("BOOL", true, true)
+	suite.ExpectDuration("DURATION", 5*time.Minute, true)
+	suite.ExpectString("HTTP/PORT", "8080", true)
+
+	// Folders are not values and we never see keys outside of the namespace.
+	suite.ExpectString("", "", false)
+	suite.ExpectString("../other/STRING", "", false)
+}
+
+func (suite ConsulSuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "Consul should be enumerable")
+	suite.Equal([]string{"BOOL", "DURATION", "HTTP/PORT", "INT", "STRING", "UINT16"}, enumerable.Keys(""))
+	suite.Equal([]string{"HTTP/PORT"}, enumerable.Keys("HTTP/"))
+	suite.Equal(map[string]string{
+		"BOOL":      "true",
+		"DURATION":  "5m",
+		"HTTP/PORT": "8080",
+		"INT":       "5",
+		"STRING":    "foo",
+		"UINT16":    "160",
+	}, configify.All(suite.Source))
+}
+
+func (suite ConsulSuite) TestNoNamespace() {
+	source, err := configify.Consul(configify.Address(suite.server.URL))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	suite.ExpectString("myapp/STRING", "foo", true)
+	suite.ExpectString("other/STRING", "other", true)
+}
+
+func (suite ConsulSuite) TestAddressWithoutScheme() {
+	source, err := configify.Consul(
+		configify.Address(suite.server.Listener.Addr().String()),
+		configify.Namespace("myapp"))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	suite.ExpectString("STRING", "foo", true)
+}
+
+func (suite ConsulSuite) TestEmptyPrefix() {
+	source, err := configify.Consul(
+		configify.Address(suite.server.URL),
+		configify.Namespace("nothing-here"),
+		configify.Defaults(configify.Values{"STRING": "default"}))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	suite.ExpectString("STRING", "default", true)
+	suite.ExpectInt("INT", 0, false)
+}
+
+func (suite ConsulSuite) TestCredentials() {
+	_, err := configify.Consul(
+		configify.Address(suite.server.URL),
+		configify.Password("secret-token"))
+	suite.Require().NoError(err)
+	suite.Equal("secret-token", suite.consul.lastRequest().Header.Get("X-Consul-Token"))
+
+	_, err = configify.Consul(
+		configify.Address(suite.server.URL),
+		configify.Username("user"),
+		configify.Password("pass"))
+	suite.Require().NoError(err)
+	username, password, ok := suite.consul.lastRequest().BasicAuth()
+	suite.True(ok)
+	suite.Equal("user", username)
+	suite.Equal("pass", password)
+}
+
+func (suite ConsulSuite) TestErrors() {
+	suite.consul.fail = true
+	_, err := configify.Consul(configify.Address(suite.server.URL))
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "500")
+
+	suite.server.Close()
+	_, err = configify.Consul(configify.Address(suite.server.URL))
+	suite.Error(err)
+}
+
+func (suite ConsulSuite) TestWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source, err := configify.Consul(
+		configify.Address(suite.server.URL),
+		configify.Namespace("myapp"),
+		configify.Context(ctx),
+		configify.RefreshInterval(time.Second))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	changes := make(chan configify.Source, 10)
+	source.Watch(func(source configify.Source) { changes <- source })
+	source.Watch(func(source configify.Source) { changes <- source })
+
+	// Changes outside of our namespace don't trigger callbacks.
+	suite.consul.set("other/STRING", "updated")
+	suite.consul.set("myapp/STRING", "updated")
+
+	suite.Require().Equal(source, suite.receive(changes))
+	suite.Require().Equal(source, suite.receive(changes))
+	suite.ExpectString("STRING", "updated", true)
+	suite.ExpectInt("INT", 5, true)
+
+	// We should have used blocking queries w/ the current index to wait for the change.
+	query := suite.consul.lastRequest().URL.Query()
+	suite.NotEmpty(query.Get("index"))
+	suite.Equal("1000ms", query.Get("wait"))
+
+	// Once the context is cancelled, nobody should hear about future changes.
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	suite.consul.set("myapp/STRING", "too late")
+	select {
+	case <-changes:
+		suite.Fail("Should not be notified after the context is cancelled")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (suite ConsulSuite) receive(changes chan configify.Source) configify.Source {
+	select {
+	case source := <-changes:
+		return source
+	case <-time.After(2 * time.Second):
+		suite.Fail("Timed out waiting for change notification")
+		return nil
+	}
+}
+
+// fakeConsul is a tiny stand-in for Consul's KV HTTP API which supports recursive lookups as
+// well as blocking queries on the index.
+type fakeConsul struct {
+	mutex    sync.Mutex
+	values   map[string]string
+	index    uint64
+	changed  chan struct{}
+	requests []*http.Request
+	fail     bool
+}
+
+func newFakeConsul(values map[string]string) *fakeConsul {
+	return &fakeConsul{values: values, index: 10, changed: make(chan struct{})}
+}
+
+func (f *fakeConsul) set(key string, value string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.values[key] = value
+	f.index++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) lastRequest() *http.Request {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.requests[len(f.requests)-1]
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mutex.Lock()
+	f.requests = append(f.requests, req)
+	index, changed, fail := f.index, f.changed, f.fail
+	f.mutex.Unlock()
+
+	if fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if requestedIndex, _ := strconv.ParseUint(req.URL.Query().Get("index"), 10, 64); requestedIndex >= index {
+		wait, _ := time.ParseDuration(req.URL.Query().Get("wait"))
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	type entry struct {
+		Key         string
+		Value       []byte
+		ModifyIndex uint64
+	}
+	prefix := strings.TrimPrefix(req.URL.Path, "/v1/kv/")
+	var entries []entry
+	for key, value := range f.values {
+		if strings.HasPrefix(key, prefix) {
+			entries = append(entries, entry{Key: key, Value: []byte(value), ModifyIndex: f.index})
+		}
+	}
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	if len(entries) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(entries)
+}
@@ -0,0 +1,149 @@
+package configify
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+var typeTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+var typeJSONUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// DecoderFunc converts the raw string value from a source into a value of some custom type. The value
+// you return should be of the type you registered the decoder for (or a type convertible to it).
+type DecoderFunc func(value string) (interface{}, error)
+
+var decoders = struct {
+	sync.RWMutex
+	funcs map[reflect.Type]DecoderFunc
+}{
+	funcs: map[reflect.Type]DecoderFunc{
+		reflect.TypeOf(url.URL{}): decodeURL,
+	},
+}
+
+// RegisterDecoder teaches the binder how to populate fields of the given type. Whenever we bind a
+// field of this type (or a pointer to it), we'll fetch the raw string value from the source and run
+// it through your decoder. This is handy for types you don't control that don't already implement
+// encoding.TextUnmarshaler. Registering a decoder for a type that already has one replaces it.
+//
+//	configify.RegisterDecoder(reflect.TypeOf(Color(0)), func(value string) (interface{}, error) {
+//		return ParseColor(value)
+//	})
+func RegisterDecoder(t reflect.Type, decoder DecoderFunc) {
+	decoders.Lock()
+	defer decoders.Unlock()
+	decoders.funcs[t] = decoder
+}
+
+func registeredDecoder(t reflect.Type) DecoderFunc {
+	decoders.RLock()
+	defer decoders.RUnlock()
+	return decoders.funcs[t]
+}
+
+// decoderFor determines how to convert a raw string into a value of type 't' when it's not one of the
+// built-in types our sources handle natively. In order of precedence, we support:
+//
+//   - Types w/ a decoder registered using RegisterDecoder()
+//   - Types that implement encoding.TextUnmarshaler (e.g. net.IP, slog.Level)
+//   - Types that implement json.Unmarshaler
+//
+// This returns nil when there's no special way to decode values of the given type.
+func decoderFor(t reflect.Type) func(string) (reflect.Value, error) {
+	// The binder handles these explicitly so that we support more formats than their UnmarshalText().
+	base := t
+	if base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+	if base == typeDuration || base == typeTime {
+		return nil
+	}
+
+	if decoder := registeredDecoder(t); decoder != nil {
+		return func(raw string) (reflect.Value, error) {
+			return callDecoder(decoder, t, raw)
+		}
+	}
+	if t.Kind() == reflect.Ptr {
+		if decoder := registeredDecoder(t.Elem()); decoder != nil {
+			return func(raw string) (reflect.Value, error) {
+				value, err := callDecoder(decoder, t.Elem(), raw)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				ptr := reflect.New(t.Elem())
+				ptr.Elem().Set(value)
+				return ptr, nil
+			}
+		}
+	}
+
+	switch {
+	case reflect.PtrTo(t).Implements(typeTextUnmarshaler):
+		return func(raw string) (reflect.Value, error) {
+			ptr := reflect.New(t)
+			err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+			return ptr.Elem(), err
+		}
+	case t.Kind() == reflect.Ptr && t.Implements(typeTextUnmarshaler):
+		return func(raw string) (reflect.Value, error) {
+			ptr := reflect.New(t.Elem())
+			err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+			return ptr, err
+		}
+	case reflect.PtrTo(t).Implements(typeJSONUnmarshaler):
+		return func(raw string) (reflect.Value, error) {
+			ptr := reflect.New(t)
+			err := unmarshalJSON(ptr.Interface().(json.Unmarshaler), raw)
+			return ptr.Elem(), err
+		}
+	case t.Kind() == reflect.Ptr && t.Implements(typeJSONUnmarshaler):
+		return func(raw string) (reflect.Value, error) {
+			ptr := reflect.New(t.Elem())
+			err := unmarshalJSON(ptr.Interface().(json.Unmarshaler), raw)
+			return ptr, err
+		}
+	}
+	return nil
+}
+
+// callDecoder runs the raw value through the decoder, making sure that we got back a value that
+// we can actually assign to a field of type 't'.
+func callDecoder(decoder DecoderFunc, t reflect.Type, raw string) (reflect.Value, error) {
+	decoded, err := decoder(raw)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	value := reflect.ValueOf(decoded)
+	switch {
+	case !value.IsValid():
+		return reflect.Zero(t), nil
+	case value.Type().AssignableTo(t):
+		return value, nil
+	case value.Type().ConvertibleTo(t):
+		return value.Convert(t), nil
+	case value.Kind() == reflect.Ptr && value.Type().Elem().AssignableTo(t):
+		return value.Elem(), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("decoder for %v returned a %T", t, decoded)
+	}
+}
+
+// unmarshalJSON feeds the raw value to the unmarshaler as-is. Since config values are rarely
+// quoted the way JSON strings are, we'll try again w/ the value as a JSON string if that fails.
+func unmarshalJSON(unmarshaler json.Unmarshaler, raw string) error {
+	if err := unmarshaler.UnmarshalJSON([]byte(raw)); err != nil {
+		return unmarshaler.UnmarshalJSON([]byte(strconv.Quote(raw)))
+	}
+	return nil
+}
+
+func decodeURL(value string) (interface{}, error) {
+	return url.Parse(value)
+}
@@ -0,0 +1,128 @@
+package configify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Directory creates a source that treats every file in the directory as a single config value: the
+// file name is the key and its contents are the value (minus any trailing newlines). This is how
+// Kubernetes mounts ConfigMaps and Secrets as volumes, but it works for any directory laid out that
+// way. Hidden files and subdirectories are ignored.
+//
+// Kubernetes updates mounted volumes atomically by writing a new timestamped directory and swapping
+// the "..data" symlink to point at it. When that symlink exists, we read every file through it so
+// you never see a mix of old and new values while the swap is happening.
+//
+// The following options control the source's behavior:
+//
+//   - Namespace/NamespaceDelim: Only files named like "NAMESPACE_KEY" are visible, just like the
+//     Environment source.
+//   - Defaults: Fallback values for keys that don't have a file.
+//   - RefreshInterval: How often we re-read the directory to look for changes once you've called
+//     Watch(). Defaults to 30 seconds.
+//   - Context: Cancel this context to stop watching for changes.
+//
+// We read the entire directory when the source is created, so you'll get an error if it doesn't
+// exist. After that, lookups never hit the disk. Watching works exactly like Poll() does, except
+// that we re-read the directory right before each check.
+func Directory(path string, opts ...Option) (SourceWatcher, error) {
+	options := apply(opts, &Options{
+		Defaults: emptySource{},
+	})
+
+	source := &directorySource{path: path}
+	source.stringSource = stringSource{name: "dir:" + path, options: *options, massage: Massage{Lenient: options.Lenient}, lookup: source.lookup}
+
+	values, err := source.read()
+	if err != nil {
+		return nil, err
+	}
+	source.values = values
+	return Poll(source, nil, opts...), nil
+}
+
+type directorySource struct {
+	stringSource
+	path   string
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+func (d *directorySource) lookup(key string) (string, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	value, ok := d.values[d.options.Namespace.Qualify(key)]
+	return value, ok
+}
+
+func (d *directorySource) Keys(prefix string) []string {
+	d.mutex.RLock()
+	names := make([]string, 0, len(d.values))
+	for name := range d.values {
+		names = append(names, name)
+	}
+	d.mutex.RUnlock()
+
+	keys := unqualifiedKeys(d.options.Namespace, names, prefix)
+	return keysWithDefaults(keys, d.options.Defaults, prefix)
+}
+
+// reload re-reads the directory so that the poller sees the latest values. The directory might
+// briefly disappear (e.g. while a volume is remounted), so we just keep the values we have and try
+// again next time.
+func (d *directorySource) reload() {
+	values, err := d.read()
+	if err != nil {
+		return
+	}
+
+	d.mutex.Lock()
+	d.values = values
+	d.mutex.Unlock()
+}
+
+// read loads the contents of every visible file in the directory, keyed by file name.
+func (d *directorySource) read() (map[string]string, error) {
+	dir := d.path
+	if resolved, err := filepath.EvalSymlinks(filepath.Join(d.path, "..data")); err == nil {
+		dir = resolved
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		// Files in the top-level directory are typically symlinks, so check what they point to.
+		file := filepath.Join(dir, entry.Name())
+		if info, err := os.Stat(file); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		value, err := readFileValue(file)
+		if err != nil {
+			return nil, err
+		}
+		values[entry.Name()] = value
+	}
+	return values, nil
+}
+
+// readFileValue reads the entire file as a single config value. Editors and "echo" like to leave
+// newlines at the end of files, so we strip those, but nothing else since spaces might be significant
+// (e.g. in a password).
+func readFileValue(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,214 @@
+package configify_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestDirectorySuite(t *testing.T) {
+	suite.Run(t, new(DirectorySuite))
+}
+
+type DirectorySuite struct {
+	configifytest.SourceSuite
+	path string
+}
+
+func (suite *DirectorySuite) SetupTest() {
+	suite.path = suite.T().TempDir()
+	suite.write(suite.path, "TEST_EMPTY", "")
+	suite.write(suite.path, "TEST_STRING", "foo\n")
+	suite.write(suite.path, "TEST_STRING_SPACE", "  foo bar \r\n\n")
+	suite.write(suite.path, "TEST_STRING_SLICE", "foo, bar, baz ,5")
+	suite.write(suite.path, "TEST_MULTILINE", "line1\nline2\n")
+	suite.write(suite.path, "TEST_INT", "5\n")
+	suite.write(suite.path, "TEST_UINT16", "160")
+	suite.write(suite.path, "TEST_BOOL_TRUE", "true\n")
+	suite.write(suite.path, "TEST_DURATION", "5m3s\n")
+	suite.write(suite.path, ".TEST_HIDDEN", "hidden")
+	suite.write(suite.path, "FOO_STRING", "foo")
+	suite.Require().NoError(os.Mkdir(filepath.Join(suite.path, "TEST_SUBDIR"), 0700))
+
+	source, err := configify.Directory(suite.path, configify.Namespace("TEST"))
+	suite.Require().NoError(err)
+	suite.Source = source
+}
+
+func (suite DirectorySuite) write(dir string, name string, value string) {
+	suite.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(value), 0600))
+}
+
+func (suite DirectorySuite) TestOptions() {
+	suite.Equal("TEST", suite.Source.Options().Namespace.Name)
+
+	_, err := configify.Directory(filepath.Join(suite.path, "not_found"))
+	suite.Error(err)
+}
+
+func (suite DirectorySuite) TestLookup() {
+	suite.ExpectString("NOT_FOUND", "", false)
+	suite.ExpectString("EMPTY", "", true)
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectString("MULTILINE", "line1\nline2", true)
+	suite.ExpectStringSlice("STRING_SLICE", []string{"foo", "bar", "baz", "5"}, true)
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectUint16("UINT16", 160, true)
+	suite.ExpectBool("BOOL_TRUE", true, true)
+	suite.ExpectDuration("DURATION", 5*time.Minute+3*time.Second, true)
+
+	// Only trailing newlines are stripped since spaces might be part of a secret.
+	value, ok := suite.Source.String("STRING_SPACE")
+	suite.True(ok)
+	suite.Equal("  foo bar ", value)
+
+	// Hidden files, directories, and files outside of the namespace aren't values.
+	suite.ExpectString("HIDDEN", "", false)
+	suite.ExpectString(".TEST_HIDDEN", "", false)
+	suite.ExpectString("SUBDIR", "", false)
+	suite.ExpectString("FOO_STRING", "", false)
+}
+
+func (suite DirectorySuite) TestDefaults() {
+	source, err := configify.Directory(suite.path,
+		configify.Namespace("TEST"),
+		configify.Defaults(configify.Values{"INT": 10, "NEW": "fallback"}))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectString("NEW", "fallback", true)
+	suite.Equal(configify.Origin{Source: "dir:" + suite.path, Key: "TEST_INT", Found: true}, configify.Explain(source, "INT"))
+}
+
+func (suite DirectorySuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "Directory should be enumerable")
+	suite.Equal([]string{"STRING", "STRING_SLICE", "STRING_SPACE"}, enumerable.Keys("STRING"))
+	suite.Len(enumerable.Keys(""), 9)
+}
+
+// TestKubernetes mimics how the kubelet mounts a ConfigMap: the files live in a timestamped directory,
+// "..data" links to it, and each key is a symlink through "..data". Updates swap the "..data" link.
+func (suite DirectorySuite) TestKubernetes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mount := suite.T().TempDir()
+	suite.writeVersion(mount, "..2024_01_01", map[string]string{"HOST": "localhost\n", "PORT": "8080\n"})
+	suite.Require().NoError(os.Symlink("..data/HOST", filepath.Join(mount, "HOST")))
+	suite.Require().NoError(os.Symlink("..data/PORT", filepath.Join(mount, "PORT")))
+
+	source, err := configify.Directory(mount,
+		configify.Context(ctx),
+		configify.RefreshInterval(10*time.Millisecond))
+	suite.Require().NoError(err)
+	suite.Source = source
+	suite.ExpectString("HOST", "localhost", true)
+	suite.ExpectInt("PORT", 8080, true)
+	suite.Equal([]string{"HOST", "PORT"}, source.(configify.Enumerable).Keys(""))
+
+	changes := make(chan configify.Source, 10)
+	source.Watch(func(source configify.Source) { changes <- source })
+	suite.expectNoChange(changes)
+
+	// The new version has a key that isn't linked from the top-level directory yet; we still see it
+	// since we read everything through "..data".
+	suite.writeVersion(mount, "..2024_01_02", map[string]string{"HOST": "example.com", "PORT": "9000", "DEBUG": "true"})
+	suite.Require().NoError(os.Symlink("..2024_01_02", filepath.Join(mount, "..data_tmp")))
+	suite.Require().NoError(os.Rename(filepath.Join(mount, "..data_tmp"), filepath.Join(mount, "..data")))
+
+	suite.Equal(source, suite.receive(changes))
+	suite.ExpectString("HOST", "example.com", true)
+	suite.ExpectInt("PORT", 9000, true)
+	suite.ExpectBool("DEBUG", true, true)
+	suite.expectNoChange(changes)
+
+	// Nobody hears about changes once the context is done.
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	suite.write(filepath.Join(mount, "..2024_01_02"), "HOST", "changed")
+	suite.expectNoChange(changes)
+}
+
+func (suite DirectorySuite) TestWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source, err := configify.Directory(suite.path,
+		configify.Namespace("TEST"),
+		configify.Context(ctx),
+		configify.RefreshInterval(10*time.Millisecond))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	changes := make(chan configify.Source, 10)
+	source.Watch(func(source configify.Source) { changes <- source })
+
+	// Modified values
+	suite.write(suite.path, "TEST_STRING", "bar\n")
+	suite.Equal(source, suite.receive(changes))
+	suite.ExpectString("STRING", "bar", true)
+
+	// Added values
+	suite.write(suite.path, "TEST_NEW", "hello")
+	suite.Equal(source, suite.receive(changes))
+	suite.ExpectString("NEW", "hello", true)
+
+	// Removed values
+	suite.Require().NoError(os.Remove(filepath.Join(suite.path, "TEST_INT")))
+	suite.Equal(source, suite.receive(changes))
+	suite.ExpectInt("INT", 0, false)
+
+	// Keep the last values we read if the directory goes away.
+	suite.Require().NoError(os.RemoveAll(suite.path))
+	suite.expectNoChange(changes)
+	suite.ExpectString("STRING", "bar", true)
+}
+
+func (suite DirectorySuite) writeVersion(mount string, version string, values map[string]string) {
+	dir := filepath.Join(mount, version)
+	suite.Require().NoError(os.Mkdir(dir, 0700))
+	for name, value := range values {
+		suite.write(dir, name, value)
+	}
+	if _, err := os.Lstat(filepath.Join(mount, "..data")); os.IsNotExist(err) {
+		suite.Require().NoError(os.Symlink(version, filepath.Join(mount, "..data")))
+	}
+}
+
+func (suite DirectorySuite) receive(changes chan configify.Source) configify.Source {
+	select {
+	case source := <-changes:
+		return source
+	case <-time.After(time.Second):
+		suite.Fail("Timed out waiting for change notification")
+		return nil
+	}
+}
+
+func (suite DirectorySuite) expectNoChange(changes chan configify.Source) {
+	select {
+	case <-changes:
+		suite.Fail("Should not have received a change notification")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func ExampleDirectory() {
+	dir, _ := os.MkdirTemp("", "configmap")
+	defer os.RemoveAll(dir)
+	_ = os.WriteFile(filepath.Join(dir, "HTTP_PORT"), []byte("8080\n"), 0600)
+
+	source, _ := configify.Directory(dir)
+	port, ok := source.Uint16("HTTP_PORT")
+	fmt.Println(port, ok)
+	// Output: 8080 true
+}
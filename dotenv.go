@@ -0,0 +1,273 @@
+package configify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DotEnv creates a source that reads the KEY=VALUE pairs defined in a ".env" style file. This
+// gives you the same behavior as Environment() without having to 'source' the file into your
+// shell first, so it's handy for local development. The file supports the usual dotenv syntax:
+//
+//	# Comments and blank lines are ignored
+//	export HTTP_HOST=localhost        # The 'export' prefix is optional
+//	HTTP_PORT = 8080
+//	GREETING="Hello\nWorld"           # Double quotes support escapes like \n, \t, \" and \$
+//	PATTERN='^[a-z]+$'                # Single quotes are completely literal
+//	CERT="-----BEGIN CERTIFICATE-----
+//	...
+//	-----END CERTIFICATE-----"        # Quoted values can span multiple lines
+//	BASE_URL=http://${HTTP_HOST}:${HTTP_PORT:-80}
+//
+// Variable expansion ($VAR, ${VAR} and ${VAR:-fallback}) resolves against values defined earlier
+// in the file first and then against the real environment. We read the file once when you create
+// the source, so later edits aren't picked up. With Namespace("MYAPP"), the key "HTTP_PORT" looks
+// for "MYAPP_HTTP_PORT" in the file (joined with NamespaceDelim), and Defaults supplies the values
+// for keys that the file doesn't define.
+func DotEnv(path string, opts ...Option) (Source, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values, err := parseDotEnv(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	options := apply(opts, &Options{
+		Defaults: emptySource{},
+	})
+	source := &dotEnvSource{values: values}
+	source.stringSource = stringSource{name: "dotenv:" + path, options: *options, massage: Massage{Lenient: options.Lenient}, lookup: source.lookup}
+	return source, nil
+}
+
+type dotEnvSource struct {
+	stringSource
+	values map[string]string
+}
+
+func (s *dotEnvSource) lookup(key string) (string, bool) {
+	value, ok := s.values[s.options.Namespace.Qualify(key)]
+	return value, ok
+}
+
+func (s *dotEnvSource) Keys(prefix string) []string {
+	names := make([]string, 0, len(s.values))
+	for name := range s.values {
+		names = append(names, name)
+	}
+	keys := unqualifiedKeys(s.options.Namespace, names, prefix)
+	return keysWithDefaults(keys, s.options.Defaults, prefix)
+}
+
+// parseDotEnv reads the entire dotenv-formatted input, returning all of the resolved key/value
+// pairs it defines. The error will indicate the line that we were unable to parse.
+func parseDotEnv(reader io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := dotEnvParser{
+		lines:  strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"),
+		values: map[string]string{},
+	}
+	for parser.next < len(parser.lines) {
+		if err = parser.parseLine(); err != nil {
+			return nil, err
+		}
+	}
+	return parser.values, nil
+}
+
+// dotEnvParser maintains the state as we work our way through the lines of a dotenv file. Since
+// quoted values can span multiple lines, a single entry may consume more than one line.
+type dotEnvParser struct {
+	lines  []string
+	next   int
+	values map[string]string
+}
+
+func (p *dotEnvParser) parseLine() error {
+	lineNumber := p.next + 1
+	line := strings.TrimSpace(p.lines[p.next])
+	p.next++
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+		line = strings.TrimSpace(line[len("export"):])
+	}
+
+	separator := strings.IndexByte(line, '=')
+	if separator < 0 {
+		return fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+	}
+	key := strings.TrimSpace(line[:separator])
+	if !isDotEnvKey(key) {
+		return fmt.Errorf("line %d: invalid key '%s'", lineNumber, key)
+	}
+
+	var value string
+	var err error
+	rawValue := strings.TrimLeft(line[separator+1:], " \t")
+	switch {
+	case strings.HasPrefix(rawValue, "'"), strings.HasPrefix(rawValue, `"`):
+		value, err = p.parseQuoted(rawValue)
+	default:
+		value = p.parseUnquoted(rawValue)
+	}
+	if err != nil {
+		return fmt.Errorf("line %d: %w", lineNumber, err)
+	}
+
+	p.values[key] = value
+	return nil
+}
+
+// parseQuoted handles values wrapped in single or double quotes, consuming additional lines
+// from the file when the closing quote is not on the same line as the opening one.
+func (p *dotEnvParser) parseQuoted(rawValue string) (string, error) {
+	quote := rawValue[0]
+	body := rawValue[1:]
+
+	closing := findClosingQuote(body, quote)
+	for closing < 0 && p.next < len(p.lines) {
+		body += "\n" + p.lines[p.next]
+		p.next++
+		closing = findClosingQuote(body, quote)
+	}
+	if closing < 0 {
+		return "", errors.New("unterminated quoted value")
+	}
+
+	trailing := strings.TrimSpace(body[closing+1:])
+	if trailing != "" && !strings.HasPrefix(trailing, "#") {
+		return "", fmt.Errorf("unexpected characters after closing quote: %s", trailing)
+	}
+
+	if quote == '\'' {
+		return body[:closing], nil
+	}
+	return p.expand(body[:closing], true), nil
+}
+
+// parseUnquoted handles bare values. These are trimmed, lose any trailing " # comment", and a
+// trailing backslash continues the value onto the next line (just like in your shell).
+func (p *dotEnvParser) parseUnquoted(rawValue string) string {
+	for strings.HasSuffix(rawValue, `\`) && p.next < len(p.lines) {
+		rawValue = rawValue[:len(rawValue)-1] + strings.TrimSpace(p.lines[p.next])
+		p.next++
+	}
+	if comment := strings.Index(rawValue, " #"); comment >= 0 {
+		rawValue = rawValue[:comment]
+	}
+	if comment := strings.Index(rawValue, "\t#"); comment >= 0 {
+		rawValue = rawValue[:comment]
+	}
+	return p.expand(strings.TrimSpace(rawValue), false)
+}
+
+// expand resolves any $VAR, ${VAR} or ${VAR:-fallback} references in the value. When 'escapes'
+// is true, we'll also convert backslash escape sequences such as \n into the characters they
+// represent; an escaped \$ is never treated as a variable reference.
+func (p *dotEnvParser) expand(value string, escapes bool) string {
+	result := strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch == '\\' && escapes && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				result.WriteByte('\n')
+			case 'r':
+				result.WriteByte('\r')
+			case 't':
+				result.WriteByte('\t')
+			case '"', '\\', '$':
+				result.WriteByte(value[i])
+			default:
+				result.WriteByte('\\')
+				result.WriteByte(value[i])
+			}
+
+		case ch == '$' && i+1 < len(value) && value[i+1] == '{':
+			end := strings.IndexByte(value[i:], '}')
+			if end < 0 {
+				result.WriteString(value[i:])
+				return result.String()
+			}
+			name, fallback, hasFallback := strings.Cut(value[i+2:i+end], ":-")
+			if resolved, ok := p.resolve(name); ok && (resolved != "" || !hasFallback) {
+				result.WriteString(resolved)
+			} else {
+				result.WriteString(fallback)
+			}
+			i += end
+
+		case ch == '$' && i+1 < len(value) && isDotEnvNameStart(value[i+1]):
+			end := i + 1
+			for end < len(value) && isDotEnvNameChar(value[end]) {
+				end++
+			}
+			resolved, _ := p.resolve(value[i+1 : end])
+			result.WriteString(resolved)
+			i = end - 1
+
+		default:
+			result.WriteByte(ch)
+		}
+	}
+	return result.String()
+}
+
+// resolve looks up the value of a variable referenced by another value. Entries defined earlier
+// in the file win over the real environment.
+func (p *dotEnvParser) resolve(name string) (string, bool) {
+	if value, ok := p.values[name]; ok {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
+
+// findClosingQuote returns the index of the first unescaped 'quote' character in the value or
+// -1 if there isn't one. Single quoted values don't support escaping at all.
+func findClosingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && quote == '"':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func isDotEnvKey(key string) bool {
+	if key == "" || !isDotEnvNameStart(key[0]) {
+		return false
+	}
+	for i := 1; i < len(key); i++ {
+		if !isDotEnvNameChar(key[i]) && key[i] != '.' && key[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDotEnvNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isDotEnvNameChar(ch byte) bool {
+	return isDotEnvNameStart(ch) || (ch >= '0' && ch <= '9')
+}
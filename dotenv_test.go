@@ -0,0 +1,200 @@
+package configify_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestDotEnvSuite(t *testing.T) {
+	suite.Run(t, new(DotEnvSuite))
+}
+
+type DotEnvSuite struct {
+	configifytest.SourceSuite
+	path string
+}
+
+func (suite *DotEnvSuite) SetupSuite() {
+	_ = os.Setenv("DOTENV_FROM_ENVIRONMENT", "real env")
+
+	suite.path = suite.writeFile(`
+# This is a comment followed by a blank line
+
+TEST_EMPTY=
+TEST_STRING=foo
+TEST_STRING_SPACE = "  foo bar "
+TEST_STRING_SLICE=foo, bar, baz ,5
+export TEST_INT=5
+export	TEST_INT8=8
+TEST_UINT16=160
+TEST_BOOL_TRUE=true
+TEST_DURATION=5m3s   # inline comments are stripped
+TEST_TIME_YYYYMMDD=2019-12-25
+TEST_FLOAT="5.430"
+TEST_HASH=foo#bar
+TEST_SINGLE='literal ${TEST_STRING} \n "quotes"'
+TEST_DOUBLE="tab\there \"quoted\" \$TEST_STRING \\ done" # comment after quotes
+TEST_ESCAPED_NEWLINE="line1\nline2"
+TEST_MULTILINE="line1
+  line2
+line3"
+TEST_MULTILINE_SINGLE='a
+b'
+TEST_CONTINUED=foo\
+  bar
+TEST_EXPAND=${TEST_STRING}-$TEST_INT-${DOTENV_FROM_ENVIRONMENT}
+TEST_EXPAND_MISSING=[${NOPE}][$NOPE]
+TEST_EXPAND_FALLBACK=${NOPE:-fallback}:${TEST_STRING:-unused}
+TEST_EXPAND_QUOTED="${TEST_STRING} bar"
+
+FOO_STRING=foo
+`)
+	source, err := configify.DotEnv(suite.path, configify.Namespace("TEST"))
+	suite.Require().NoError(err)
+	suite.Source = source
+}
+
+func (suite DotEnvSuite) writeFile(content string) string {
+	path := filepath.Join(suite.T().TempDir(), ".env")
+	suite.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func (suite DotEnvSuite) TestOptions() {
+	source, err := configify.DotEnv(suite.path,
+		configify.Namespace("FOO"),
+		configify.NamespaceDelim("."))
+
+	suite.Require().NoError(err)
+	suite.Equal("FOO", source.Options().Namespace.Name)
+	suite.Equal(".", source.Options().Namespace.Delimiter)
+}
+
+func (suite DotEnvSuite) TestString() {
+	suite.ExpectString("NOT_FOUND", "", false)
+	suite.ExpectString("EMPTY", "", true)
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectString("STRING_SPACE", "  foo bar ", true)
+	suite.ExpectString("DURATION", "5m3s", true)
+	suite.ExpectString("HASH", "foo#bar", true)
+
+	// Does not fetch values from other namespaces
+	suite.ExpectString("FOO_STRING", "", false)
+}
+
+func (suite DotEnvSuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "DotEnv should be enumerable")
+	suite.Equal([]string{"EXPAND", "EXPAND_FALLBACK", "EXPAND_MISSING", "EXPAND_QUOTED"}, enumerable.Keys("EXPAND"))
+	suite.NotContains(enumerable.Keys(""), "FOO_STRING")
+	suite.Equal("foo", configify.All(suite.Source)["STRING"])
+}
+
+func (suite DotEnvSuite) TestQuotes() {
+	suite.ExpectString("SINGLE", `literal ${TEST_STRING} \n "quotes"`, true)
+	suite.ExpectString("DOUBLE", "tab\there \"quoted\" $TEST_STRING \\ done", true)
+	suite.ExpectString("ESCAPED_NEWLINE", "line1\nline2", true)
+	suite.ExpectString("MULTILINE", "line1\n  line2\nline3", true)
+	suite.ExpectString("MULTILINE_SINGLE", "a\nb", true)
+	suite.ExpectString("CONTINUED", "foobar", true)
+}
+
+func (suite DotEnvSuite) TestExpansion() {
+	suite.ExpectString("EXPAND", "foo-5-real env", true)
+	suite.ExpectString("EXPAND_MISSING", "[][]", true)
+	suite.ExpectString("EXPAND_FALLBACK", "fallback:foo", true)
+	suite.ExpectString("EXPAND_QUOTED", "foo bar", true)
+}
+
+func (suite DotEnvSuite) TestTypes() {
+	suite.ExpectStringSlice("STRING_SLICE", []string{"foo", "bar", "baz", "5"}, true)
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectInt8("INT8", int8(8), true)
+	suite.ExpectUint16("UINT16", uint16(160), true)
+	suite.ExpectBool("BOOL_TRUE", true, true)
+	suite.ExpectFloat64("FLOAT", 5.43, true)
+	suite.ExpectDuration("DURATION", 5*time.Minute+3*time.Second, true)
+	suite.ExpectTime("TIME_YYYYMMDD", time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC), true)
+
+	suite.ExpectInt("STRING", 0, false)
+	suite.ExpectBool("NOT_FOUND", false, false)
+}
+
+func (suite DotEnvSuite) TestDefaults() {
+	source, err := configify.DotEnv(suite.path,
+		configify.Namespace("TEST"),
+		configify.Defaults(configify.Values{
+			"STRING_MOCK": "asdf",
+			"INT_MOCK":    8,
+		}))
+	suite.Require().NoError(err)
+	suite.Source = source
+
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectString("STRING_MOCK", "asdf", true)
+	suite.ExpectString("STRING_XXX", "", false)
+
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectInt("INT_MOCK", 8, true)
+	suite.ExpectInt("INT_XXX", 0, false)
+}
+
+func (suite DotEnvSuite) TestErrors() {
+	_, err := configify.DotEnv(filepath.Join(suite.T().TempDir(), "does-not-exist.env"))
+	suite.True(errors.Is(err, os.ErrNotExist))
+
+	_, err = configify.DotEnv(suite.writeFile("FOO=1\nBAR\n"))
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "line 2")
+
+	_, err = configify.DotEnv(suite.writeFile("FOO=1\n1BAR=2\n"))
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "line 2")
+
+	_, err = configify.DotEnv(suite.writeFile("FOO=1\nBAR=\"unterminated\nBAZ=3\n"))
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "line 2")
+
+	_, err = configify.DotEnv(suite.writeFile("FOO='foo' bar\n"))
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "line 1")
+}
+
+func ExampleDotEnv() {
+	// Normally you'd just point this at the ".env" file in your project.
+	path := filepath.Join(os.TempDir(), "example.env")
+	_ = os.WriteFile(path, []byte(`
+		# Settings for local development
+		HELLO_HOST=localhost
+		export HELLO_PORT=8080
+		HELLO_URL="http://${HELLO_HOST}:${HELLO_PORT}"
+	`), 0600)
+	defer os.Remove(path)
+
+	config, err := configify.DotEnv(path, configify.Namespace("HELLO"))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	host, ok := config.String("HOST")
+	fmt.Printf("Host: [%s] (%v)\n", host, ok)
+
+	port, ok := config.Uint16("PORT")
+	fmt.Printf("Port: [%d] (%v)\n", port, ok)
+
+	url, ok := config.String("URL")
+	fmt.Printf("URL:  [%s] (%v)\n", url, ok)
+
+	// Output: Host: [localhost] (true)
+	// Port: [8080] (true)
+	// URL:  [http://localhost:8080] (true)
+}
@@ -18,6 +18,14 @@ func (emptySource) Options() Options {
 	return Options{}
 }
 
+func (emptySource) Keys(string) []string {
+	return nil
+}
+
+func (emptySource) Explain(key string) Origin {
+	return Origin{Key: key}
+}
+
 func (emptySource) String(string) (string, bool) {
 	return "", false
 }
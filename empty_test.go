@@ -17,6 +17,13 @@ type DefaultSuite struct {
 	configifytest.SourceSuite
 }
 
+func (suite DefaultSuite) TestKeys() {
+	enumerable, ok := configify.Empty().(configify.Enumerable)
+	suite.Require().True(ok, "Empty should be enumerable")
+	suite.Empty(enumerable.Keys(""))
+	suite.Empty(configify.All(configify.Empty()))
+}
+
 func (suite DefaultSuite) TestAll() {
 	suite.Source = configify.Empty()
 	options := suite.Source.Options()
@@ -3,6 +3,7 @@ package configify_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -50,6 +51,9 @@ func (suite *EnvironmentSuite) SetupSuite() {
 	suite.set("TEST_LARGE_FLOAT", "5,300,123.430")
 	suite.set("TEST_NEGATIVE_FLOAT", "-5.1")
 	suite.set("TEST_JUST_FLOAT", ".1")
+	suite.set("TEST_WHOLE_FLOAT", "1,200.00")
+	suite.set("TEST_OVERFLOW", "300")
+	suite.set("TEST_LARGE_OVERFLOW", "70000")
 
 	// Not part of the "test namespace"
 	suite.set("FOO_EMPTY", "")
@@ -117,9 +121,12 @@ func (suite EnvironmentSuite) TestInt() {
 	suite.ExpectInt("INT", 5, true)
 	suite.ExpectInt("LARGE_INT", 5300123, true)
 	suite.ExpectInt("NEGATIVE", -3, true)
-	suite.ExpectInt("FLOAT", 5, true)
-	suite.ExpectInt("NEGATIVE_FLOAT", -5, true)
-	suite.ExpectInt("LARGE_FLOAT", 5300123, true)
+	suite.ExpectInt("WHOLE_FLOAT", 1200, true)
+
+	// We don't silently truncate decimal values unless you ask us to be lenient.
+	suite.ExpectInt("FLOAT", 0, false)
+	suite.ExpectInt("NEGATIVE_FLOAT", 0, false)
+	suite.ExpectInt("LARGE_FLOAT", 0, false)
 	suite.ExpectInt("JUST_FLOAT", 0, false)
 
 	// Does not fetch values from other namespaces
@@ -137,6 +144,10 @@ func (suite EnvironmentSuite) TestInt8() {
 	suite.ExpectInt8("INT8", int8(8), true)
 	suite.ExpectInt8("INT16", int8(16), true)
 
+	// Values that don't fit are not ok rather than wrapping around.
+	suite.ExpectInt8("OVERFLOW", int8(0), false)
+	suite.ExpectInt8("NEGATIVE", int8(-3), true)
+
 	// Does not fetch values from other namespaces
 	suite.ExpectInt("FOO_INT", 0, false)
 }
@@ -148,7 +159,8 @@ func (suite EnvironmentSuite) TestInt16() {
 	suite.ExpectInt16("STRING", int16(0), false)
 	suite.ExpectInt16("INT", int16(5), true)
 	suite.ExpectInt16("INT16", int16(16), true)
-	suite.ExpectInt16("INT16", int16(16), true)
+	suite.ExpectInt16("OVERFLOW", int16(300), true)
+	suite.ExpectInt16("LARGE_OVERFLOW", int16(0), false)
 
 	// Does not fetch values from other namespaces
 	suite.ExpectInt("FOO_INT", 0, false)
@@ -162,6 +174,7 @@ func (suite EnvironmentSuite) TestInt32() {
 	suite.ExpectInt32("INT", int32(5), true)
 	suite.ExpectInt32("INT32", int32(32), true)
 	suite.ExpectInt32("INT16", int32(16), true)
+	suite.ExpectInt32("LARGE_OVERFLOW", int32(70000), true)
 
 	// Does not fetch values from other namespaces
 	suite.ExpectInt("FOO_INT", 0, false)
@@ -189,8 +202,9 @@ func (suite EnvironmentSuite) TestUint() {
 	suite.ExpectUint("STRING_SLICE", uint(0), false)
 	suite.ExpectUint("INT", uint(5), true)
 	suite.ExpectUint("LARGE_INT", uint(5300123), true)
-	suite.ExpectUint("FLOAT", uint(5), true)
-	suite.ExpectUint("LARGE_FLOAT", uint(5300123), true)
+	suite.ExpectUint("WHOLE_FLOAT", uint(1200), true)
+	suite.ExpectUint("FLOAT", uint(0), false)
+	suite.ExpectUint("LARGE_FLOAT", uint(0), false)
 	suite.ExpectUint("JUST_FLOAT", uint(0), false)
 
 	// Negatives resolve to zero, not the value w/o the minus sign.
@@ -211,6 +225,7 @@ func (suite EnvironmentSuite) TestUint8() {
 	suite.ExpectUint8("UINT", uint8(90), true)
 	suite.ExpectUint8("UINT8", uint8(80), true)
 	suite.ExpectUint8("UINT16", uint8(160), true)
+	suite.ExpectUint8("OVERFLOW", uint8(0), false)
 
 	// Does not fetch values from other namespaces
 	suite.ExpectUint("FOO_UINT", 0, false)
@@ -223,7 +238,8 @@ func (suite EnvironmentSuite) TestUint16() {
 	suite.ExpectUint16("STRING", uint16(0), false)
 	suite.ExpectUint16("UINT", uint16(90), true)
 	suite.ExpectUint16("UINT16", uint16(160), true)
-	suite.ExpectUint16("UINT16", uint16(160), true)
+	suite.ExpectUint16("OVERFLOW", uint16(300), true)
+	suite.ExpectUint16("LARGE_OVERFLOW", uint16(0), false)
 
 	// Does not fetch values from other namespaces
 	suite.ExpectUint("FOO_UINT", 0, false)
@@ -255,6 +271,25 @@ func (suite EnvironmentSuite) TestUint64() {
 	suite.ExpectUint("FOO_UINT", 0, false)
 }
 
+func (suite EnvironmentSuite) TestLenient() {
+	suite.Source = configify.Environment(configify.Namespace("TEST"), configify.Lenient())
+	suite.True(suite.Source.Options().Lenient)
+
+	suite.ExpectInt("FLOAT", 5, true)
+	suite.ExpectInt("NEGATIVE_FLOAT", -5, true)
+	suite.ExpectInt("LARGE_FLOAT", 5300123, true)
+	suite.ExpectInt("JUST_FLOAT", 0, false)
+	suite.ExpectUint("FLOAT", uint(5), true)
+	suite.ExpectUint("LARGE_FLOAT", uint(5300123), true)
+	suite.ExpectUint("NEGATIVE_FLOAT", uint(0), false)
+	suite.ExpectInt8("FLOAT", int8(5), true)
+
+	// Being lenient about decimals doesn't mean we let things overflow.
+	suite.ExpectInt8("OVERFLOW", int8(0), false)
+	suite.ExpectUint16("LARGE_OVERFLOW", uint16(0), false)
+	suite.ExpectInt("STRING", 0, false)
+}
+
 func (suite EnvironmentSuite) TestFloat64() {
 	suite.ExpectFloat64("NOT_FOUND", float64(0), false)
 	suite.ExpectFloat64("FLOAT", 5.43, true)
@@ -277,6 +312,22 @@ func (suite EnvironmentSuite) TestFloat32() {
 	suite.ExpectFloat32("STRING", float32(0), false)
 	suite.ExpectFloat32("STRING_SLICE", float32(0), false)
 	suite.ExpectFloat32("LARGE_INT", float32(0), false)
+
+	// Values are rounded to the nearest float32, but they can't be too large to fit in one.
+	_ = os.Setenv("FLOAT32_TEST_ROUNDED", "5.430000001")
+	_ = os.Setenv("FLOAT32_TEST_OVERFLOW", "1e40")
+	defer os.Unsetenv("FLOAT32_TEST_ROUNDED")
+	defer os.Unsetenv("FLOAT32_TEST_OVERFLOW")
+	source := configify.Environment(configify.Namespace("FLOAT32_TEST"))
+	number, ok := source.Float32("ROUNDED")
+	suite.True(ok)
+	suite.Equal(float32(5.43), number)
+	number, ok = source.Float32("OVERFLOW")
+	suite.False(ok)
+	suite.Equal(float32(0), number)
+	float64Number, ok := source.Float64("OVERFLOW")
+	suite.True(ok)
+	suite.Equal(1e40, float64Number)
 }
 
 func (suite EnvironmentSuite) TestBool() {
@@ -341,6 +392,70 @@ func (suite EnvironmentSuite) TestDefaults() {
 	suite.ExpectUint("UINT_XXX", uint(0), false)
 }
 
+func (suite EnvironmentSuite) TestFileIndirection() {
+	dir := suite.T().TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	_ = os.WriteFile(passwordFile, []byte("  hunter2 \n"), 0600)
+	portFile := filepath.Join(dir, "port")
+	_ = os.WriteFile(portFile, []byte("8080\n"), 0600)
+
+	suite.set("FILE_PASSWORD_FILE", passwordFile)
+	suite.set("FILE_PORT_FILE", " "+portFile+" ")
+	suite.set("FILE_HOST", "localhost")
+	suite.set("FILE_HOST_FILE", passwordFile)
+	suite.set("FILE_MISSING_FILE", filepath.Join(dir, "not_found"))
+	suite.set("FILE_EMPTY_FILE", "")
+	defer func() {
+		for _, name := range []string{"FILE_PASSWORD_FILE", "FILE_PORT_FILE", "FILE_HOST", "FILE_HOST_FILE", "FILE_MISSING_FILE", "FILE_EMPTY_FILE"} {
+			_ = os.Unsetenv(name)
+		}
+	}()
+
+	// Without the option, the "_FILE" variables are just like any other.
+	suite.Source = configify.Environment(configify.Namespace("FILE"))
+	suite.ExpectString("PASSWORD", "", false)
+	suite.ExpectString("PASSWORD_FILE", passwordFile, true)
+
+	suite.Source = configify.Environment(configify.Namespace("FILE"), configify.FileIndirection("_FILE"))
+	suite.ExpectString("PASSWORD", "hunter2", true)
+	suite.ExpectUint16("PORT", 8080, true)
+	suite.ExpectString("PASSWORD_FILE", passwordFile, true)
+
+	// The variable itself always wins over the file.
+	suite.ExpectString("HOST", "localhost", true)
+
+	// Files we can't read are just missing values.
+	suite.ExpectString("MISSING", "", false)
+	suite.ExpectString("EMPTY", "", false)
+	suite.ExpectString("NOT_FOUND", "", false)
+
+	keys := suite.Source.(configify.Enumerable).Keys("")
+	suite.Subset(keys, []string{"HOST", "HOST_FILE", "PASSWORD", "PASSWORD_FILE", "PORT", "PORT_FILE"})
+}
+
+func (suite EnvironmentSuite) TestKeys() {
+	source := configify.Environment(
+		configify.Namespace("TEST"),
+		configify.Defaults(configify.Values{
+			"STRING_MOCK": "asdf",
+			"STRING":      "duplicate",
+			"INT_MOCK":    8,
+		}))
+	enumerable, ok := source.(configify.Enumerable)
+	suite.Require().True(ok, "Environment should be enumerable")
+
+	// Keys are unqualified and include the ones we'd fall back to in the defaults.
+	suite.Equal([]string{"STRING", "STRING_MOCK", "STRING_SLICE", "STRING_SPACE"}, enumerable.Keys("STRING"))
+	suite.Equal([]string{"TIME_RFC3339", "TIME_YYYYMMDD"}, enumerable.Keys("TIME_"))
+	suite.Empty(enumerable.Keys("NOT_FOUND"))
+
+	keys := enumerable.Keys("")
+	suite.Contains(keys, "EMPTY")
+	suite.Contains(keys, "INT_MOCK")
+	suite.NotContains(keys, "FOO_STRING")
+	suite.NotContains(keys, "STRING_FOO")
+}
+
 func ExampleEnvironment() {
 	// Obviously, these would be normally applied by whatever you're using
 	// for orchestration.
@@ -373,7 +488,7 @@ func ExampleEnvironment() {
 	// Foo:     [] (false)
 }
 
-func ExampleEnvironmentDefaults() {
+func ExampleEnvironment_defaults() {
 	// Obviously, these would be normally applied by whatever you're using
 	// for orchestration.
 	_ = os.Setenv("HELLO_HOST", "localhost")
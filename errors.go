@@ -0,0 +1,93 @@
+package configify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidValue indicates that a config value exists in the source, but it can't be converted
+// to the type that you asked for (e.g. "HTTP_PORT=abc" when binding to a uint16 field).
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrRequired indicates that a field was tagged as required (e.g. `conf:"DATABASE_URL,required"`),
+// but the source did not have a value for it.
+var ErrRequired = errors.New("missing required value")
+
+// ErrInvalidDefault indicates that the default value defined in a field's struct tag can't be
+// converted to the field's type (e.g. `default:"thirty seconds"` on a time.Duration).
+var ErrInvalidDefault = errors.New("invalid default value")
+
+// FieldError describes a single struct field that the binder was unable to populate.
+type FieldError struct {
+	// Field is the Go-style path to the struct field (e.g. "HTTP.Port").
+	Field string
+	// Key is the fully namespace-qualified config key we used (e.g. "MYAPP_HTTP_PORT").
+	Key string
+	// Value is the raw value that the source supplied for this key.
+	Value string
+	// Type is the type of the struct field we tried to populate.
+	Type reflect.Type
+	// Err describes what went wrong (e.g. ErrInvalidValue).
+	Err error
+}
+
+func (e FieldError) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrInvalidValue):
+		return fmt.Sprintf("%s (%s): unable to parse '%s' as %v", e.Key, e.Field, e.Value, e.Type)
+	case errors.Is(e.Err, ErrInvalidDefault):
+		return fmt.Sprintf("%s (%s): unable to parse default value '%s' as %v", e.Key, e.Field, e.Value, e.Type)
+	default:
+		return fmt.Sprintf("%s (%s): %v", e.Key, e.Field, e.Err)
+	}
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// BindError is returned by Binder.BindE when one or more fields could not be populated. Rather
+// than failing on the first bad value, it collects every problem so you can fix them all at once.
+type BindError struct {
+	Fields []FieldError
+}
+
+func (e *BindError) Error() string {
+	var messages []string
+	if missing := e.Missing(); len(missing) > 0 {
+		messages = append(messages, "missing configuration: "+strings.Join(missing, ", "))
+	}
+	for _, field := range e.Fields {
+		if !errors.Is(field.Err, ErrRequired) {
+			messages = append(messages, field.Error())
+		}
+	}
+	return "configify: " + strings.Join(messages, "; ")
+}
+
+// Missing returns the fully namespace-qualified keys of every required field that did not have a
+// value in the source. These are the exact names of the variables/keys you need to set.
+func (e *BindError) Missing() []string {
+	var keys []string
+	for _, field := range e.Fields {
+		if errors.Is(field.Err, ErrRequired) {
+			keys = append(keys, field.Key)
+		}
+	}
+	return keys
+}
+
+// Unwrap exposes the individual field errors so that errors.Is() and errors.As() can inspect them.
+func (e *BindError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+func (e *BindError) add(fieldError FieldError) {
+	e.Fields = append(e.Fields, fieldError)
+}
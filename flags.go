@@ -0,0 +1,69 @@
+package configify
+
+import (
+	"flag"
+	"strings"
+)
+
+// Flags creates a source that pulls config values from the command-line flags in the given flag set.
+// Only flags that were explicitly set on the command line are visible, so flag defaults never shadow
+// values from the sources layered beneath this one. Flag names map to config keys by upper-casing them
+// and converting dashes and dots to the NamespaceDelim, so "--http-port" supplies the value for
+// "HTTP_PORT" (or "HTTP.PORT" with NamespaceDelim(".")).
+//
+//	fs := flag.NewFlagSet("myapp", flag.ExitOnError)
+//	fs.Int("http-port", 8080, "The port to listen on")
+//	fs.Parse(os.Args[1:])
+//
+//	source := configify.Chain(configify.Flags(fs), configify.Environment())
+//
+// Flags are read when you look up a value rather than when you create the source, so it's fine to
+// parse the flag set afterwards. With Namespace("HTTP"), the "--http-port" flag supplies "PORT", and
+// Defaults supplies the values for flags that weren't set.
+func Flags(fs *flag.FlagSet, opts ...Option) Source {
+	options := apply(opts, &Options{
+		Defaults: emptySource{},
+	})
+	source := &flagSource{flags: fs}
+	source.stringSource = stringSource{name: "flags", options: *options, massage: Massage{Lenient: options.Lenient}, lookup: source.lookup}
+	return source
+}
+
+type flagSource struct {
+	stringSource
+	flags *flag.FlagSet
+}
+
+func (s *flagSource) lookup(key string) (string, bool) {
+	value, ok := s.values()[s.options.Namespace.Qualify(key)]
+	return value, ok
+}
+
+func (s *flagSource) Keys(prefix string) []string {
+	values := s.values()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	keys := unqualifiedKeys(s.options.Namespace, names, prefix)
+	return keysWithDefaults(keys, s.options.Defaults, prefix)
+}
+
+// values returns the current values of all of the flags that were explicitly set, keyed by the config
+// key that each flag name maps to.
+func (s *flagSource) values() map[string]string {
+	values := map[string]string{}
+	if s.flags == nil {
+		return values
+	}
+	s.flags.Visit(func(f *flag.Flag) {
+		values[flagKey(f.Name, s.options.Namespace.delimiter())] = strings.TrimSpace(f.Value.String())
+	})
+	return values
+}
+
+// flagKey converts a flag name such as "http-port" to its config key "HTTP_PORT", joining the words
+// using the namespace delimiter.
+func flagKey(name string, delimiter string) string {
+	return strings.ToUpper(strings.NewReplacer("-", delimiter, ".", delimiter).Replace(name))
+}
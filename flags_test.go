@@ -0,0 +1,151 @@
+package configify_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFlagsSuite(t *testing.T) {
+	suite.Run(t, new(FlagsSuite))
+}
+
+type FlagsSuite struct {
+	configifytest.SourceSuite
+	flags *flag.FlagSet
+}
+
+func (suite *FlagsSuite) SetupTest() {
+	suite.flags = flag.NewFlagSet("test", flag.ContinueOnError)
+	suite.flags.String("string", "", "")
+	suite.flags.String("string-space", "", "")
+	suite.flags.String("string-slice", "", "")
+	suite.flags.String("not-set", "default", "")
+	suite.flags.Int("http-port", 80, "")
+	suite.flags.Int("overflow", 0, "")
+	suite.flags.Bool("verbose", false, "")
+	suite.flags.Duration("db.timeout", time.Second, "")
+	suite.flags.Float64("ratio", 0, "")
+
+	suite.Require().NoError(suite.flags.Parse([]string{
+		"--string=foo",
+		"--string-space", "  foo bar ",
+		"-string-slice=foo, bar, baz ,5",
+		"--http-port=8080",
+		"--overflow=300",
+		"--verbose",
+		"--db.timeout=5m3s",
+		"--ratio=2.5",
+		"positional",
+	}))
+	suite.Source = configify.Flags(suite.flags)
+}
+
+func (suite FlagsSuite) TestOptions() {
+	source := configify.Flags(suite.flags, configify.Namespace("DB"))
+	suite.Equal("DB", source.Options().Namespace.Name)
+}
+
+func (suite FlagsSuite) TestLookup() {
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectString("STRING_SPACE", "foo bar", true)
+	suite.ExpectStringSlice("STRING_SLICE", []string{"foo", "bar", "baz", "5"}, true)
+	suite.ExpectInt("HTTP_PORT", 8080, true)
+	suite.ExpectUint16("HTTP_PORT", 8080, true)
+	suite.ExpectInt8("OVERFLOW", 0, false)
+	suite.ExpectBool("VERBOSE", true, true)
+	suite.ExpectDuration("DB_TIMEOUT", 5*time.Minute+3*time.Second, true)
+	suite.ExpectFloat64("RATIO", 2.5, true)
+
+	// Flags that weren't set on the command line don't expose their default values.
+	suite.ExpectString("NOT_SET", "", false)
+	suite.ExpectString("NOT_FOUND", "", false)
+	suite.ExpectString("http-port", "", false)
+}
+
+func (suite FlagsSuite) TestNamespace() {
+	suite.Source = configify.Flags(suite.flags, configify.Namespace("DB"))
+	suite.ExpectDuration("TIMEOUT", 5*time.Minute+3*time.Second, true)
+	suite.ExpectString("STRING", "", false)
+	suite.Equal(map[string]string{"TIMEOUT": "5m3s"}, configify.All(suite.Source))
+
+	// Flag names are split using the namespace's delimiter, so nested structs resolve the same way.
+	suite.Source = configify.Flags(suite.flags, configify.Namespace("DB"), configify.NamespaceDelim("."))
+	suite.ExpectDuration("TIMEOUT", 5*time.Minute+3*time.Second, true)
+	suite.Source = configify.Flags(suite.flags, configify.NamespaceDelim("."))
+	suite.ExpectInt("HTTP.PORT", 8080, true)
+	suite.ExpectInt("HTTP_PORT", 0, false)
+
+	config := struct {
+		HTTP struct{ Port int }
+	}{}
+	chain := configify.Chain(
+		configify.Flags(suite.flags, configify.NamespaceDelim(".")),
+		configify.Map(configify.Values{"HTTP": configify.Values{"PORT": 80}}, configify.NamespaceDelim(".")))
+	suite.NoError(configify.NewBinder(chain).BindE(&config))
+	suite.Equal(8080, config.HTTP.Port)
+}
+
+func (suite FlagsSuite) TestDefaults() {
+	suite.Source = configify.Flags(suite.flags, configify.Defaults(configify.Values{
+		"NOT_SET":   "fallback",
+		"HTTP_PORT": 9000,
+	}))
+	suite.ExpectString("NOT_SET", "fallback", true)
+	suite.ExpectInt("HTTP_PORT", 8080, true)
+}
+
+func (suite FlagsSuite) TestParsedLater() {
+	flags := flag.NewFlagSet("later", flag.ContinueOnError)
+	flags.Int("http-port", 80, "")
+	suite.Source = configify.Flags(flags)
+	suite.ExpectInt("HTTP_PORT", 0, false)
+
+	suite.Require().NoError(flags.Parse([]string{"--http-port=8080"}))
+	suite.ExpectInt("HTTP_PORT", 8080, true)
+
+	suite.Source = configify.Flags(nil)
+	suite.ExpectInt("HTTP_PORT", 0, false)
+}
+
+func (suite FlagsSuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "Flags should be enumerable")
+
+	// Only the flags that were actually set show up.
+	suite.Equal([]string{"DB_TIMEOUT", "HTTP_PORT", "OVERFLOW", "RATIO", "STRING", "STRING_SLICE", "STRING_SPACE", "VERBOSE"}, enumerable.Keys(""))
+	suite.Equal([]string{"STRING", "STRING_SLICE", "STRING_SPACE"}, enumerable.Keys("STRING"))
+}
+
+func (suite FlagsSuite) TestExplain() {
+	suite.Equal(configify.Origin{Source: "flags", Key: "HTTP_PORT", Found: true}, configify.Explain(suite.Source, "HTTP_PORT"))
+	suite.Equal(configify.Origin{Key: "NOT_SET"}, configify.Explain(suite.Source, "NOT_SET"))
+}
+
+func ExampleFlags() {
+	_ = os.Setenv("EXAMPLE_HTTP_HOST", "example.com")
+	_ = os.Setenv("EXAMPLE_HTTP_PORT", "8080")
+
+	flags := flag.NewFlagSet("example", flag.ContinueOnError)
+	flags.String("http-host", "localhost", "The host to listen on")
+	flags.Int("http-port", 80, "The port to listen on")
+	_ = flags.Parse([]string{"--http-port=9000"})
+
+	config := struct {
+		HTTPHost string `conf:"HTTP_HOST"`
+		HTTPPort int    `conf:"HTTP_PORT"`
+	}{}
+	source := configify.Chain(
+		configify.Flags(flags),
+		configify.Environment(configify.Namespace("EXAMPLE")),
+	)
+	configify.NewBinder(source).Bind(&config)
+	fmt.Println(config.HTTPHost, config.HTTPPort)
+	// Output: example.com 9000
+}
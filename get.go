@@ -0,0 +1,50 @@
+package configify
+
+import (
+	"reflect"
+)
+
+// Get looks up the key's value as any type that the binder supports: built-ins like int8 or
+// time.Duration, slices, maps, pointers, types that implement encoding.TextUnmarshaler, or types
+// with a decoder registered via RegisterDecoder(). The value is parsed exactly as it would be if
+// you bound it to a struct field of that type, so you don't need a Source method for every type.
+//
+//	port, ok := configify.Get[uint16](source, "HTTP_PORT")
+//	hosts, ok := configify.Get[[]net.IP](source, "DNS_SERVERS")
+//
+// You can even ask for a struct, in which case the key is the prefix for all of its fields. The
+// result is only ok if the source had a value for at least one of them.
+func Get[T any](source Source, key string) (T, bool) {
+	var value T
+	if source == nil {
+		return value, false
+	}
+
+	binder := standardBinder{Source: source, errors: &BindError{}}
+	field := reflect.StructField{Name: key, Type: reflect.TypeOf(&value).Elem()}
+	ok := binder.updateValue(field, reflect.ValueOf(&value).Elem(), key, "")
+	return value, ok
+}
+
+// GetOr looks up the key's value just like Get, but returns the fallback value if the source doesn't
+// have a value for the key (or if the value can't be converted to your type).
+func GetOr[T any](source Source, key string, fallback T) T {
+	if value, ok := Get[T](source, key); ok {
+		return value
+	}
+	return fallback
+}
+
+// MustGet looks up the key's value just like Get, but panics if the source doesn't have a value for
+// the key or if the value can't be converted to your type. The panic value is an error that matches
+// ErrNotFound or is a *ParseError, respectively. This is handy for values your program can't run without.
+func MustGet[T any](source Source, key string) T {
+	value, ok := Get[T](source, key)
+	if !ok {
+		if source == nil {
+			source = emptySource{}
+		}
+		panic(lookupError(source, key, reflect.TypeOf(&value).Elem()))
+	}
+	return value
+}
@@ -0,0 +1,138 @@
+package configify_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestGetSuite(t *testing.T) {
+	suite.Run(t, new(GetSuite))
+}
+
+type GetSuite struct {
+	suite.Suite
+	source configify.Source
+}
+
+func (suite *GetSuite) SetupSuite() {
+	_ = os.Setenv("GET_STRING", "foo")
+	_ = os.Setenv("GET_PORT", "8080")
+	_ = os.Setenv("GET_OVERFLOW", "300")
+	_ = os.Setenv("GET_TIMEOUT", "5s")
+	_ = os.Setenv("GET_DELAYS", "1s,2s")
+	_ = os.Setenv("GET_LABELS", "team=core")
+	_ = os.Setenv("GET_IP", "10.0.0.1")
+	_ = os.Setenv("GET_LEVEL", "warn")
+	_ = os.Setenv("GET_NESTED_INNER_INT", "5")
+	suite.source = configify.Environment(configify.Namespace("GET"))
+}
+
+func (suite GetSuite) TestGet() {
+	str, ok := configify.Get[string](suite.source, "STRING")
+	suite.True(ok)
+	suite.Equal("foo", str)
+
+	port, ok := configify.Get[uint16](suite.source, "PORT")
+	suite.True(ok)
+	suite.Equal(uint16(8080), port)
+
+	timeout, ok := configify.Get[time.Duration](suite.source, "TIMEOUT")
+	suite.True(ok)
+	suite.Equal(5*time.Second, timeout)
+
+	delays, ok := configify.Get[[]time.Duration](suite.source, "DELAYS")
+	suite.True(ok)
+	suite.Equal([]time.Duration{time.Second, 2 * time.Second}, delays)
+
+	labels, ok := configify.Get[map[string]string](suite.source, "LABELS")
+	suite.True(ok)
+	suite.Equal(map[string]string{"team": "core"}, labels)
+
+	ip, ok := configify.Get[net.IP](suite.source, "IP")
+	suite.True(ok)
+	suite.Equal(net.ParseIP("10.0.0.1"), ip)
+
+	level, ok := configify.Get[logLevel](suite.source, "LEVEL")
+	suite.True(ok)
+	suite.Equal(logLevel(2), level)
+
+	pointer, ok := configify.Get[*int](suite.source, "PORT")
+	suite.True(ok)
+	suite.Require().NotNil(pointer)
+	suite.Equal(8080, *pointer)
+
+	nested, ok := configify.Get[Nested](suite.source, "NESTED")
+	suite.True(ok)
+	suite.Equal(5, nested.InnerInt)
+}
+
+func (suite GetSuite) TestGetMissing() {
+	port, ok := configify.Get[uint16](suite.source, "NOT_FOUND")
+	suite.False(ok)
+	suite.Equal(uint16(0), port)
+
+	pointer, ok := configify.Get[*int](suite.source, "NOT_FOUND")
+	suite.False(ok)
+	suite.Nil(pointer)
+
+	_, ok = configify.Get[int8](suite.source, "OVERFLOW")
+	suite.False(ok)
+
+	_, ok = configify.Get[Nested](suite.source, "NOT_FOUND")
+	suite.False(ok)
+
+	_, ok = configify.Get[string](nil, "STRING")
+	suite.False(ok)
+}
+
+func (suite GetSuite) TestGetOr() {
+	suite.Equal(uint16(8080), configify.GetOr[uint16](suite.source, "PORT", 9000))
+	suite.Equal(uint16(9000), configify.GetOr[uint16](suite.source, "NOT_FOUND", 9000))
+	suite.Equal(int8(1), configify.GetOr[int8](suite.source, "OVERFLOW", 1))
+	suite.Equal(time.Minute, configify.GetOr(suite.source, "NOT_FOUND", time.Minute))
+}
+
+func (suite GetSuite) TestMustGet() {
+	suite.Equal(uint16(8080), configify.MustGet[uint16](suite.source, "PORT"))
+
+	suite.Panics(func() { configify.MustGet[uint16](suite.source, "NOT_FOUND") })
+	err := suite.recoverError(func() { configify.MustGet[uint16](suite.source, "NOT_FOUND") })
+	suite.True(errors.Is(err, configify.ErrNotFound))
+
+	err = suite.recoverError(func() { configify.MustGet[int8](suite.source, "OVERFLOW") })
+	var parseErr *configify.ParseError
+	suite.Require().True(errors.As(err, &parseErr))
+	suite.Equal("GET_OVERFLOW", parseErr.QualifiedKey)
+
+	err = suite.recoverError(func() { configify.MustGet[string](nil, "STRING") })
+	suite.True(errors.Is(err, configify.ErrNotFound))
+}
+
+func (suite GetSuite) recoverError(fn func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	fn()
+	return nil
+}
+
+func ExampleGet() {
+	source := configify.Map(configify.Values{
+		"TIMEOUTS": []string{"1s", "5s", "30s"},
+	})
+
+	timeouts, ok := configify.Get[[]time.Duration](source, "TIMEOUTS")
+	fmt.Println(timeouts, ok)
+
+	port := configify.GetOr[uint16](source, "HTTP_PORT", 8080)
+	fmt.Println(port)
+	// Output: [1s 5s 30s] true
+	// 8080
+}
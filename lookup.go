@@ -0,0 +1,164 @@
+package configify
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ErrNotFound indicates that the source does not have any value for the key you looked up.
+var ErrNotFound = errors.New("value not found")
+
+// ParseError indicates that the source has a value for the key you looked up, but that it can't be
+// converted to the type you asked for (e.g. "HTTP_PORT=abc" when you want a uint16). It matches
+// ErrInvalidValue when using errors.Is().
+type ParseError struct {
+	// Key is the unqualified key that you looked up (e.g. "HTTP_PORT").
+	Key string
+	// QualifiedKey is the fully namespace-qualified key (e.g. "MYAPP_HTTP_PORT").
+	QualifiedKey string
+	// Value is the raw value that the source has for this key.
+	Value string
+	// Type is the type that we were unable to convert the value to.
+	Type reflect.Type
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("configify: %s: unable to parse '%s' as %v", e.QualifiedKey, e.Value, e.Type)
+}
+
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidValue
+}
+
+// StringE looks up the string value for the key, returning an error that matches ErrNotFound when
+// the source doesn't have a value for it. This is the error-aware version of Source.String().
+func StringE(source Source, key string) (string, error) {
+	return lookupE(source, key, Source.String)
+}
+
+// StringSliceE looks up the string slice value for the key. The error matches ErrNotFound if the
+// source doesn't have a value for it or is a *ParseError if it can't be converted to a slice.
+func StringSliceE(source Source, key string) ([]string, error) {
+	return lookupE(source, key, Source.StringSlice)
+}
+
+// IntE looks up the int value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to an int.
+func IntE(source Source, key string) (int, error) {
+	return lookupE(source, key, Source.Int)
+}
+
+// Int8E looks up the int8 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to an int8.
+func Int8E(source Source, key string) (int8, error) {
+	return lookupE(source, key, Source.Int8)
+}
+
+// Int16E looks up the int16 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to an int16.
+func Int16E(source Source, key string) (int16, error) {
+	return lookupE(source, key, Source.Int16)
+}
+
+// Int32E looks up the int32 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to an int32.
+func Int32E(source Source, key string) (int32, error) {
+	return lookupE(source, key, Source.Int32)
+}
+
+// Int64E looks up the int64 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to an int64.
+func Int64E(source Source, key string) (int64, error) {
+	return lookupE(source, key, Source.Int64)
+}
+
+// UintE looks up the uint value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a uint.
+func UintE(source Source, key string) (uint, error) {
+	return lookupE(source, key, Source.Uint)
+}
+
+// Uint8E looks up the uint8 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a uint8.
+func Uint8E(source Source, key string) (uint8, error) {
+	return lookupE(source, key, Source.Uint8)
+}
+
+// Uint16E looks up the uint16 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a uint16.
+func Uint16E(source Source, key string) (uint16, error) {
+	return lookupE(source, key, Source.Uint16)
+}
+
+// Uint32E looks up the uint32 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a uint32.
+func Uint32E(source Source, key string) (uint32, error) {
+	return lookupE(source, key, Source.Uint32)
+}
+
+// Uint64E looks up the uint64 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a uint64.
+func Uint64E(source Source, key string) (uint64, error) {
+	return lookupE(source, key, Source.Uint64)
+}
+
+// Float32E looks up the float32 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a float32.
+func Float32E(source Source, key string) (float32, error) {
+	return lookupE(source, key, Source.Float32)
+}
+
+// Float64E looks up the float64 value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a float64.
+func Float64E(source Source, key string) (float64, error) {
+	return lookupE(source, key, Source.Float64)
+}
+
+// BoolE looks up the bool value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a bool.
+func BoolE(source Source, key string) (bool, error) {
+	return lookupE(source, key, Source.Bool)
+}
+
+// DurationE looks up the duration value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a time.Duration.
+func DurationE(source Source, key string) (time.Duration, error) {
+	return lookupE(source, key, Source.Duration)
+}
+
+// TimeE looks up the time value for the key. The error matches ErrNotFound if the source doesn't
+// have a value for it or is a *ParseError if it can't be converted to a time.Time.
+func TimeE(source Source, key string) (time.Time, error) {
+	return lookupE(source, key, Source.Time)
+}
+
+// lookupE invokes the getter, figuring out why it failed if the source didn't give us a value.
+func lookupE[T any](source Source, key string, getter func(Source, string) (T, bool)) (T, error) {
+	if source == nil {
+		source = emptySource{}
+	}
+	value, ok := getter(source, key)
+	if ok {
+		return value, nil
+	}
+	var zero T
+	return zero, lookupError(source, key, reflect.TypeOf(&zero).Elem())
+}
+
+// lookupError distinguishes between keys that the source simply doesn't have and keys whose raw
+// values we couldn't convert to the type you asked for.
+func lookupError(source Source, key string, t reflect.Type) error {
+	qualifiedKey := Explain(source, key).Key
+	raw, ok := source.String(key)
+	if !ok {
+		return fmt.Errorf("configify: %s: %w", qualifiedKey, ErrNotFound)
+	}
+	return &ParseError{
+		Key:          key,
+		QualifiedKey: qualifiedKey,
+		Value:        raw,
+		Type:         t,
+	}
+}
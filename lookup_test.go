@@ -0,0 +1,153 @@
+package configify_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLookupSuite(t *testing.T) {
+	suite.Run(t, new(LookupSuite))
+}
+
+type LookupSuite struct {
+	suite.Suite
+	source configify.Source
+}
+
+func (suite *LookupSuite) SetupSuite() {
+	_ = os.Setenv("LOOKUP_STRING", "foo")
+	_ = os.Setenv("LOOKUP_SLICE", "a,b")
+	_ = os.Setenv("LOOKUP_INT", "5")
+	_ = os.Setenv("LOOKUP_OVERFLOW", "300")
+	_ = os.Setenv("LOOKUP_FLOAT", "1.5")
+	_ = os.Setenv("LOOKUP_BOOL", "true")
+	_ = os.Setenv("LOOKUP_DURATION", "5m")
+	_ = os.Setenv("LOOKUP_TIME", "2019-12-25")
+	suite.source = configify.Environment(configify.Namespace("LOOKUP"))
+}
+
+func (suite LookupSuite) TestFound() {
+	str, err := configify.StringE(suite.source, "STRING")
+	suite.NoError(err)
+	suite.Equal("foo", str)
+
+	slice, err := configify.StringSliceE(suite.source, "SLICE")
+	suite.NoError(err)
+	suite.Equal([]string{"a", "b"}, slice)
+
+	number, err := configify.IntE(suite.source, "INT")
+	suite.NoError(err)
+	suite.Equal(5, number)
+
+	int16Number, err := configify.Int16E(suite.source, "OVERFLOW")
+	suite.NoError(err)
+	suite.Equal(int16(300), int16Number)
+
+	uint64Number, err := configify.Uint64E(suite.source, "INT")
+	suite.NoError(err)
+	suite.Equal(uint64(5), uint64Number)
+
+	float, err := configify.Float64E(suite.source, "FLOAT")
+	suite.NoError(err)
+	suite.Equal(1.5, float)
+
+	flag, err := configify.BoolE(suite.source, "BOOL")
+	suite.NoError(err)
+	suite.Equal(true, flag)
+
+	duration, err := configify.DurationE(suite.source, "DURATION")
+	suite.NoError(err)
+	suite.Equal(5*time.Minute, duration)
+
+	date, err := configify.TimeE(suite.source, "TIME")
+	suite.NoError(err)
+	suite.Equal(time.Date(2019, 12, 25, 0, 0, 0, 0, time.UTC), date)
+}
+
+func (suite LookupSuite) TestNotFound() {
+	_, err := configify.StringE(suite.source, "NOT_FOUND")
+	suite.True(errors.Is(err, configify.ErrNotFound))
+	suite.False(errors.Is(err, configify.ErrInvalidValue))
+	suite.Equal("configify: LOOKUP_NOT_FOUND: value not found", err.Error())
+
+	number, err := configify.Uint16E(suite.source, "NOT_FOUND")
+	suite.True(errors.Is(err, configify.ErrNotFound))
+	suite.Equal(uint16(0), number)
+
+	_, err = configify.IntE(nil, "INT")
+	suite.True(errors.Is(err, configify.ErrNotFound))
+}
+
+func (suite LookupSuite) TestParseError() {
+	number, err := configify.Int8E(suite.source, "OVERFLOW")
+	suite.Equal(int8(0), number)
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	suite.False(errors.Is(err, configify.ErrNotFound))
+
+	var parseErr *configify.ParseError
+	suite.Require().True(errors.As(err, &parseErr))
+	suite.Equal("OVERFLOW", parseErr.Key)
+	suite.Equal("LOOKUP_OVERFLOW", parseErr.QualifiedKey)
+	suite.Equal("300", parseErr.Value)
+	suite.Equal(reflect.TypeOf(int8(0)), parseErr.Type)
+	suite.Equal("configify: LOOKUP_OVERFLOW: unable to parse '300' as int8", err.Error())
+
+	_, err = configify.DurationE(suite.source, "STRING")
+	suite.Require().True(errors.As(err, &parseErr))
+	suite.Equal(reflect.TypeOf(time.Duration(0)), parseErr.Type)
+
+	_, err = configify.TimeE(suite.source, "INT")
+	suite.Require().True(errors.As(err, &parseErr))
+	suite.Equal("5", parseErr.Value)
+
+	_, err = configify.BoolE(suite.source, "STRING")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	_, err = configify.Float32E(suite.source, "STRING")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	_, err = configify.UintE(suite.source, "FLOAT")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	_, err = configify.Int32E(suite.source, "FLOAT")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	_, err = configify.Int64E(suite.source, "FLOAT")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	_, err = configify.Uint8E(suite.source, "OVERFLOW")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	_, err = configify.Uint32E(suite.source, "STRING")
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+}
+
+func (suite LookupSuite) TestChainKeys() {
+	// The qualified key comes from the source in the chain that would supply the value.
+	chain := configify.Chain(suite.source, configify.Map(configify.Values{"PORT": 80}))
+	_, err := configify.Int8E(chain, "OVERFLOW")
+	var parseErr *configify.ParseError
+	suite.Require().True(errors.As(err, &parseErr))
+	suite.Equal("LOOKUP_OVERFLOW", parseErr.QualifiedKey)
+
+	_, err = configify.IntE(chain, "NOT_FOUND")
+	suite.Equal("configify: LOOKUP_NOT_FOUND: value not found", err.Error())
+}
+
+func ExampleUint16E() {
+	_ = os.Setenv("EXAMPLE_HTTP_PORT", "abc")
+	source := configify.Environment(configify.Namespace("EXAMPLE"))
+
+	_, err := configify.Uint16E(source, "HTTP_PORT")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, configify.ErrInvalidValue))
+
+	_, err = configify.Uint16E(source, "GRPC_PORT")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, configify.ErrNotFound))
+	// Output: configify: EXAMPLE_HTTP_PORT: unable to parse 'abc' as uint16
+	// true
+	// configify: EXAMPLE_GRPC_PORT: value not found
+	// true
+}
@@ -1,139 +1,366 @@
 package configify
 
 import (
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // Map creates a hard-coded map of config values. You can use these as a source on their own
-// or you can provide them as the fallback defaults for other sources.
-func Map(values Values) Source {
-	return &mapSource{values: values}
+// or you can provide them as the fallback defaults for other sources. Values are converted
+// between compatible representations much like the environment's strings are, so you can ask
+// for the int 8080 as a uint16, the string "5s" as a duration, or any number as a string.
+// Integers are range-checked and floats only become integers when they have no fractional part.
+//
+// You can nest Values (or any map[string]any) to group related keys. Nested keys are joined
+// using the namespace delimiter, so these are equivalent:
+//
+//	configify.Map(configify.Values{"HTTP": configify.Values{"PORT": 8080, "TLS": configify.Values{"ENABLED": true}}})
+//	configify.Map(configify.Values{"HTTP_PORT": 8080, "HTTP_TLS_ENABLED": true})
+//
+// When a nested key and a flat one collide, the flat one wins. This source honors the Namespace
+// and NamespaceDelim options; all other options are ignored.
+func Map(values Values, opts ...Option) Source {
+	options := apply(opts, &Options{})
+	return &mapSource{
+		options: *options,
+		nested:  values,
+		values:  flattenValues(values, options.Namespace),
+	}
 }
 
 type mapSource struct {
+	options Options
+	// nested contains the values exactly as they were given to us so we can re-flatten them when
+	// they're used as the defaults for a source with a different delimiter.
+	nested Values
+	// values contains the fully flattened "KEY" -> value pairs that we actually perform lookups on.
 	values Values
 }
 
 func (s mapSource) Options() Options {
-	return Options{}
+	return s.options
 }
 
-func (s mapSource) String(key string) (string, bool) {
-	if val, ok := s.values[key].(string); ok {
-		return strings.TrimSpace(val), true
+func (s mapSource) Keys(prefix string) []string {
+	names := make([]string, 0, len(s.values))
+	for name := range s.values {
+		names = append(names, name)
 	}
-	return "", false
+	return uniqueSorted(unqualifiedKeys(s.options.Namespace, names, prefix))
+}
+
+func (s mapSource) Explain(key string) Origin {
+	qualifiedKey := s.options.Namespace.Qualify(key)
+	if _, ok := s.values[qualifiedKey]; !ok {
+		return Origin{Key: qualifiedKey}
+	}
+	return Origin{Source: "map", Key: qualifiedKey, Found: true}
+}
+
+// value returns the raw, flattened value for the namespace-qualified key.
+func (s mapSource) value(key string) interface{} {
+	return s.values[s.options.Namespace.Qualify(key)]
+}
+
+func (s mapSource) String(key string) (string, bool) {
+	return mapToString(s.value(key))
 }
 
 func (s mapSource) StringSlice(key string) ([]string, bool) {
-	if val, ok := s.values[key].([]string); ok {
+	switch val := s.value(key).(type) {
+	case nil:
+		return nil, false
+	case []string:
 		return val, true
+	case string:
+		return Massage{}.StringToSlice(strings.TrimSpace(val))
 	}
-	return nil, false
+
+	value := reflect.ValueOf(s.value(key))
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+	slice := make([]string, value.Len())
+	for i := range slice {
+		element, ok := mapToString(value.Index(i).Interface())
+		if !ok {
+			return nil, false
+		}
+		slice[i] = element
+	}
+	return slice, true
 }
 
 func (s mapSource) Int(key string) (int, bool) {
-	if val, ok := s.values[key].(int); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.intSize(key, strconv.IntSize)
+	return int(val), ok
 }
 
 func (s mapSource) Int8(key string) (int8, bool) {
-	if val, ok := s.values[key].(int8); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.intSize(key, 8)
+	return int8(val), ok
 }
 
 func (s mapSource) Int16(key string) (int16, bool) {
-	if val, ok := s.values[key].(int16); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.intSize(key, 16)
+	return int16(val), ok
 }
 
 func (s mapSource) Int32(key string) (int32, bool) {
-	if val, ok := s.values[key].(int32); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.intSize(key, 32)
+	return int32(val), ok
 }
 
 func (s mapSource) Int64(key string) (int64, bool) {
-	if val, ok := s.values[key].(int64); ok {
-		return val, true
-	}
-	return 0, false
+	return s.intSize(key, 64)
 }
 
 func (s mapSource) Uint(key string) (uint, bool) {
-	if val, ok := s.values[key].(uint); ok {
-		return val, true
-	}
-	return uint(0), false
+	val, ok := s.uintSize(key, strconv.IntSize)
+	return uint(val), ok
 }
 
 func (s mapSource) Uint8(key string) (uint8, bool) {
-	if val, ok := s.values[key].(uint8); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.uintSize(key, 8)
+	return uint8(val), ok
 }
 
 func (s mapSource) Uint16(key string) (uint16, bool) {
-	if val, ok := s.values[key].(uint16); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.uintSize(key, 16)
+	return uint16(val), ok
 }
 
 func (s mapSource) Uint32(key string) (uint32, bool) {
-	if val, ok := s.values[key].(uint32); ok {
-		return val, true
-	}
-	return 0, false
+	val, ok := s.uintSize(key, 32)
+	return uint32(val), ok
 }
 
 func (s mapSource) Uint64(key string) (uint64, bool) {
-	if val, ok := s.values[key].(uint64); ok {
-		return val, true
-	}
-	return 0, false
+	return s.uintSize(key, 64)
 }
 
 func (s mapSource) Float64(key string) (float64, bool) {
-	if val, ok := s.values[key].(float64); ok {
-		return val, true
-	}
-	return 0.0, false
+	return s.floatSize(key, 64)
 }
 
 func (s mapSource) Float32(key string) (float32, bool) {
-	if val, ok := s.values[key].(float32); ok {
-		return float32(val), true
-	}
-	return 0.0, false
+	val, ok := s.floatSize(key, 32)
+	return float32(val), ok
 }
 
 func (s mapSource) Bool(key string) (bool, bool) {
-	if val, ok := s.values[key].(bool); ok {
+	switch val := s.value(key).(type) {
+	case bool:
 		return val, true
+	case string:
+		return Massage{}.StringToBool(strings.TrimSpace(val))
 	}
 	return false, false
 }
 
 func (s mapSource) Duration(key string) (time.Duration, bool) {
-	if val, ok := s.values[key].(time.Duration); ok {
+	switch val := s.value(key).(type) {
+	case time.Duration:
 		return val, true
+	case string:
+		return Massage{}.StringToDuration(strings.TrimSpace(val))
 	}
 	return time.Duration(0), false
 }
 
 func (s mapSource) Time(key string) (time.Time, bool) {
-	if val, ok := s.values[key].(time.Time); ok {
+	switch val := s.value(key).(type) {
+	case time.Time:
 		return val, true
+	case string:
+		return Massage{}.StringToTime(strings.TrimSpace(val))
 	}
 	return time.Time{}, false
 }
+
+// intSize converts the key's value to a signed integer that fits in the given number of bits. Values
+// of any integer type work as long as they're in range, as do floats without a fractional part and
+// strings that the environment would accept.
+func (s mapSource) intSize(key string, bitSize int) (int64, bool) {
+	if val, ok := s.value(key).(string); ok {
+		return Massage{}.StringToIntSize(strings.TrimSpace(val), bitSize)
+	}
+
+	var number int64
+	value, ok := mapNumber(s.value(key))
+	switch {
+	case !ok:
+		return 0, false
+	case value.CanInt():
+		number = value.Int()
+	case value.CanUint():
+		if value.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		number = int64(value.Uint())
+	default:
+		float := value.Float()
+		if float != math.Trunc(float) || float < math.MinInt64 || float >= math.MaxInt64 {
+			return 0, false
+		}
+		number = int64(float)
+	}
+
+	limit := int64(1) << (bitSize - 1)
+	if bitSize < 64 && (number < -limit || number >= limit) {
+		return 0, false
+	}
+	return number, true
+}
+
+// uintSize converts the key's value to an unsigned integer that fits in the given number of bits,
+// following the same rules as intSize. Negative values are never ok.
+func (s mapSource) uintSize(key string, bitSize int) (uint64, bool) {
+	if val, ok := s.value(key).(string); ok {
+		return Massage{}.StringToUintSize(strings.TrimSpace(val), bitSize)
+	}
+
+	var number uint64
+	value, ok := mapNumber(s.value(key))
+	switch {
+	case !ok:
+		return 0, false
+	case value.CanUint():
+		number = value.Uint()
+	case value.CanInt():
+		if value.Int() < 0 {
+			return 0, false
+		}
+		number = uint64(value.Int())
+	default:
+		float := value.Float()
+		if float != math.Trunc(float) || float < 0 || float >= math.MaxUint64 {
+			return 0, false
+		}
+		number = uint64(float)
+	}
+
+	if bitSize < 64 && number >= uint64(1)<<bitSize {
+		return 0, false
+	}
+	return number, true
+}
+
+// floatSize converts the key's value to a float. Integers are only ok when the float can represent
+// them exactly, but float32 values are rounded just like when parsing the environment's strings (so
+// 5.43 becomes 5.4299998). We only reject values that are too large to fit in a float32 at all.
+func (s mapSource) floatSize(key string, bitSize int) (float64, bool) {
+	if val, ok := s.value(key).(string); ok {
+		number, err := strconv.ParseFloat(strings.TrimSpace(val), bitSize)
+		return number, err == nil
+	}
+
+	var number float64
+	value, ok := mapNumber(s.value(key))
+	switch {
+	case !ok:
+		return 0, false
+	case value.CanInt():
+		number = float64(value.Int())
+		if value.Int() != int64(number) {
+			return 0, false
+		}
+	case value.CanUint():
+		number = float64(value.Uint())
+		if number >= math.MaxUint64 || value.Uint() != uint64(number) {
+			return 0, false
+		}
+	default:
+		number = value.Float()
+	}
+
+	if bitSize == 32 && math.IsInf(float64(float32(number)), 0) && !math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
+}
+
+// mapNumber returns the reflected value when the map value is some sort of integer or float. Durations
+// don't count since there's no sensible unit to assume when converting them to/from plain numbers.
+func mapNumber(value any) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := value.(time.Duration); ok {
+		return reflect.Value{}, false
+	}
+	reflected := reflect.ValueOf(value)
+	if !reflected.CanInt() && !reflected.CanUint() && !reflected.CanFloat() {
+		return reflect.Value{}, false
+	}
+	return reflected, true
+}
+
+// mapToString formats a map value the way it would look in the environment, so strings, numbers,
+// booleans, durations and times can all be looked up as strings.
+func mapToString(value any) (string, bool) {
+	switch val := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return strings.TrimSpace(val), true
+	case bool:
+		return strconv.FormatBool(val), true
+	case time.Duration:
+		return val.String(), true
+	case time.Time:
+		return val.Format(time.RFC3339Nano), true
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch {
+	case reflected.Kind() == reflect.String:
+		return strings.TrimSpace(reflected.String()), true
+	case reflected.CanInt():
+		return strconv.FormatInt(reflected.Int(), 10), true
+	case reflected.CanUint():
+		return strconv.FormatUint(reflected.Uint(), 10), true
+	case reflected.CanFloat():
+		bitSize := 64
+		if reflected.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, bitSize), true
+	}
+	return "", false
+}
+
+// flattenValues converts any nested Values (or map[string]any) into flat keys that are joined using
+// the namespace's delimiter (e.g. "HTTP" -> "PORT" becomes "HTTP_PORT").
+func flattenValues(values Values, ns namespace) Values {
+	flat := Values{}
+	flattenInto(flat, values, "", ns)
+	return flat
+}
+
+func flattenInto(flat Values, values map[string]interface{}, prefix string, ns namespace) {
+	for key, value := range values {
+		if nested, ok := nestedValues(value); ok {
+			flattenInto(flat, nested, ns.Join(prefix, key), ns)
+		}
+	}
+	// Flat keys are written last so that they win over nested ones that resolve to the same key.
+	for key, value := range values {
+		if _, ok := nestedValues(value); !ok {
+			flat[ns.Join(prefix, key)] = value
+		}
+	}
+}
+
+func nestedValues(value interface{}) (map[string]interface{}, bool) {
+	switch nested := value.(type) {
+	case Values:
+		return nested, true
+	case map[string]interface{}:
+		return nested, true
+	}
+	return nil, false
+}
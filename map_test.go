@@ -2,6 +2,7 @@ package configify_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -44,21 +45,49 @@ func (suite *MapSuite) SetupSuite() {
 		"BOOL_FALSE":         false,
 		"LARGE_FLOAT":        5300123.430,
 		"NEGATIVE_FLOAT":     -5.1,
+		"WHOLE_FLOAT":        1200.0,
 		"DURATION":           5 * time.Minute,
 		"TIME":               time.Date(2019, time.December, 25, 8, 33, 40, 0, time.UTC),
+		"TEXT_INT":           " 8,080 ",
+		"TEXT_FLOAT":         "2.5",
+		"TEXT_BOOL":          "TRUE",
+		"TEXT_DURATION":      "5m3s",
+		"TEXT_TIME":          "2019-12-25",
+		"TEXT_SLICE":         "foo, bar ,baz",
+		"ANY_SLICE":          []any{"foo", 5, 2.5, true},
+		"INT_SLICE":          []int{1, 2, 3},
+		"NESTED_SLICE":       []any{"foo", []string{"bar"}},
+		"CUSTOM_INT":         portNumber(8080),
+		"CUSTOM_STRING":      logName(" access "),
 	})
 }
 
+// portNumber makes sure that named types (like logName) work in maps, not just the built-in ones.
+type portNumber int
+
 func (suite MapSuite) TestString() {
 	suite.ExpectString("NOT_FOUND", "", false)
 	suite.ExpectString("EMPTY", "", true)
 	suite.ExpectString("STRING", "foo", true)
 	suite.ExpectString("STRING_SPACE", "foo bar", true)
-
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectString("CUSTOM_STRING", "access", true)
+
+	// Other scalars look just like they would in the environment.
+	suite.ExpectString("INT", "5", true)
+	suite.ExpectString("NEGATIVE", "-3", true)
+	suite.ExpectString("UINT64", "640", true)
+	suite.ExpectString("CUSTOM_INT", "8080", true)
+	suite.ExpectString("FLOAT32", "2.89", true)
+	suite.ExpectString("FLOAT64", "5.43", true)
+	suite.ExpectString("WHOLE_FLOAT", "1200", true)
+	suite.ExpectString("BOOL_TRUE", "true", true)
+	suite.ExpectString("DURATION", "5m0s", true)
+	suite.ExpectString("TIME", "2019-12-25T08:33:40Z", true)
+
+	// Slices aren't scalars.
 	suite.ExpectString("STRING_SLICE", "", false)
-	suite.ExpectString("INT", "", false)
-	suite.ExpectString("FLOAT64", "", false)
+	suite.ExpectString("ANY_SLICE", "", false)
+	suite.ExpectString("STRING_SLICE_NIL", "", false)
 }
 
 func (suite MapSuite) TestStringSlice() {
@@ -69,9 +98,15 @@ func (suite MapSuite) TestStringSlice() {
 	// We can't distinguish between nil you explicitly put in and nil that never existed.
 	suite.ExpectStringSlice("STRING_SLICE_NIL", []string{}, false)
 
-	// Only values strongly typed as []string will resolve properly.
-	suite.ExpectStringSlice("EMPTY", []string{}, false)
-	suite.ExpectStringSlice("STRING", []string{}, false)
+	// Strings are split on commas just like in the environment and other slices convert each element.
+	suite.ExpectStringSlice("EMPTY", []string{}, true)
+	suite.ExpectStringSlice("STRING", []string{"foo"}, true)
+	suite.ExpectStringSlice("TEXT_SLICE", []string{"foo", "bar", "baz"}, true)
+	suite.ExpectStringSlice("ANY_SLICE", []string{"foo", "5", "2.5", "true"}, true)
+	suite.ExpectStringSlice("INT_SLICE", []string{"1", "2", "3"}, true)
+
+	// Every element must be a scalar.
+	suite.ExpectStringSlice("NESTED_SLICE", []string{}, false)
 	suite.ExpectStringSlice("INT", []string{}, false)
 	suite.ExpectStringSlice("FLOAT64", []string{}, false)
 }
@@ -81,146 +116,171 @@ func (suite MapSuite) TestInt() {
 	suite.ExpectInt("INT", 5, true)
 	suite.ExpectInt("LARGE_INT", 5300123, true)
 	suite.ExpectInt("NEGATIVE", -3, true)
-
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectInt("UINT", 5, true)
+	suite.ExpectInt("INT8", 8, true)
+	suite.ExpectInt("CUSTOM_INT", 8080, true)
+	suite.ExpectInt("WHOLE_FLOAT", 1200, true)
+	suite.ExpectInt("TEXT_INT", 8080, true)
+
+	// Floats must not lose their fraction, and nothing else is a number.
+	suite.ExpectInt("FLOAT64", 0, false)
+	suite.ExpectInt("NEGATIVE_FLOAT", 0, false)
 	suite.ExpectInt("EMPTY", 0, false)
 	suite.ExpectInt("STRING", 0, false)
+	suite.ExpectInt("TEXT_FLOAT", 0, false)
 	suite.ExpectInt("STRING_SLICE", 0, false)
-	suite.ExpectInt("UINT", 0, false)
+	suite.ExpectInt("BOOL_TRUE", 0, false)
+	suite.ExpectInt("DURATION", 0, false)
 }
 
 func (suite MapSuite) TestInt8() {
 	suite.ExpectInt8("NOT_FOUND", int8(0), false)
 	suite.ExpectInt8("INT8", int8(8), true)
+	suite.ExpectInt8("INT", int8(5), true)
+	suite.ExpectInt8("NEGATIVE", int8(-3), true)
+	suite.ExpectInt8("UINT8", int8(80), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	// Values must fit in 8 bits.
+	suite.ExpectInt8("LARGE_INT", int8(0), false)
+	suite.ExpectInt8("UINT16", int8(0), false)
+	suite.ExpectInt8("WHOLE_FLOAT", int8(0), false)
+	suite.ExpectInt8("TEXT_INT", int8(0), false)
+	suite.ExpectInt8("FLOAT64", int8(0), false)
 	suite.ExpectInt8("EMPTY", int8(0), false)
 	suite.ExpectInt8("STRING", int8(0), false)
 	suite.ExpectInt8("STRING_SLICE", int8(0), false)
-	suite.ExpectInt8("INT", int8(0), false)
-	suite.ExpectInt8("LARGE_INT", int8(0), false)
-	suite.ExpectInt8("NEGATIVE", int8(0), false)
-	suite.ExpectInt8("FLOAT64", int8(0), false)
 }
 
 func (suite MapSuite) TestInt16() {
 	suite.ExpectInt16("NOT_FOUND", int16(0), false)
 	suite.ExpectInt16("INT16", int16(16), true)
+	suite.ExpectInt16("INT", int16(5), true)
+	suite.ExpectInt16("NEGATIVE", int16(-3), true)
+	suite.ExpectInt16("UINT16", int16(160), true)
+	suite.ExpectInt16("WHOLE_FLOAT", int16(1200), true)
+	suite.ExpectInt16("TEXT_INT", int16(8080), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	// Values must fit in 16 bits.
+	suite.ExpectInt16("LARGE_INT", int16(0), false)
+	suite.ExpectInt16("FLOAT64", int16(0), false)
 	suite.ExpectInt16("EMPTY", int16(0), false)
 	suite.ExpectInt16("STRING", int16(0), false)
 	suite.ExpectInt16("STRING_SLICE", int16(0), false)
-	suite.ExpectInt16("INT", int16(0), false)
-	suite.ExpectInt16("LARGE_INT", int16(0), false)
-	suite.ExpectInt16("NEGATIVE", int16(0), false)
-	suite.ExpectInt16("FLOAT64", int16(0), false)
 }
 
 func (suite MapSuite) TestInt32() {
 	suite.ExpectInt32("NOT_FOUND", int32(0), false)
 	suite.ExpectInt32("INT32", int32(32), true)
+	suite.ExpectInt32("INT", int32(5), true)
+	suite.ExpectInt32("LARGE_INT", int32(5300123), true)
+	suite.ExpectInt32("NEGATIVE", int32(-3), true)
+	suite.ExpectInt32("UINT32", int32(320), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectInt32("FLOAT64", int32(0), false)
 	suite.ExpectInt32("EMPTY", int32(0), false)
 	suite.ExpectInt32("STRING", int32(0), false)
 	suite.ExpectInt32("STRING_SLICE", int32(0), false)
-	suite.ExpectInt32("INT", int32(0), false)
-	suite.ExpectInt32("LARGE_INT", int32(0), false)
-	suite.ExpectInt32("NEGATIVE", int32(0), false)
-	suite.ExpectInt32("FLOAT64", int32(0), false)
 }
 
 func (suite MapSuite) TestInt64() {
 	suite.ExpectInt64("NOT_FOUND", int64(0), false)
 	suite.ExpectInt64("INT64", int64(64), true)
+	suite.ExpectInt64("INT", int64(5), true)
+	suite.ExpectInt64("LARGE_INT", int64(5300123), true)
+	suite.ExpectInt64("NEGATIVE", int64(-3), true)
+	suite.ExpectInt64("UINT64", int64(640), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectInt64("FLOAT64", int64(0), false)
 	suite.ExpectInt64("EMPTY", int64(0), false)
 	suite.ExpectInt64("STRING", int64(0), false)
 	suite.ExpectInt64("STRING_SLICE", int64(0), false)
-	suite.ExpectInt64("INT", int64(0), false)
-	suite.ExpectInt64("LARGE_INT", int64(0), false)
-	suite.ExpectInt64("NEGATIVE", int64(0), false)
-	suite.ExpectInt64("FLOAT64", int64(0), false)
+	suite.ExpectInt64("DURATION", int64(0), false)
+
+	// Values beyond the range of an int64 aren't ok either.
+	suite.Source = configify.Map(configify.Values{"HUGE": uint64(math.MaxUint64), "HUGE_FLOAT": 1e20})
+	suite.ExpectInt64("HUGE", int64(0), false)
+	suite.ExpectInt64("HUGE_FLOAT", int64(0), false)
 }
 
 func (suite MapSuite) TestUint() {
 	suite.ExpectUint("NOT_FOUND", uint(0), false)
 	suite.ExpectUint("UINT", uint(5), true)
+	suite.ExpectUint("INT", uint(5), true)
+	suite.ExpectUint("LARGE_INT", uint(5300123), true)
+	suite.ExpectUint("WHOLE_FLOAT", uint(1200), true)
+	suite.ExpectUint("TEXT_INT", uint(8080), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	// Negative values are never unsigned.
+	suite.ExpectUint("NEGATIVE", uint(0), false)
+	suite.ExpectUint("NEGATIVE_FLOAT", uint(0), false)
+	suite.ExpectUint("FLOAT64", uint(0), false)
 	suite.ExpectUint("EMPTY", uint(0), false)
 	suite.ExpectUint("STRING", uint(0), false)
 	suite.ExpectUint("STRING_SLICE", uint(0), false)
-	suite.ExpectUint("INT", uint(0), false)
-	suite.ExpectUint("LARGE_INT", uint(0), false)
-	suite.ExpectUint("NEGATIVE", uint(0), false)
-	suite.ExpectUint("FLOAT64", uint(0), false)
 }
 
 func (suite MapSuite) TestUint8() {
 	suite.ExpectUint8("NOT_FOUND", uint8(0), false)
 	suite.ExpectUint8("UINT8", uint8(80), true)
+	suite.ExpectUint8("INT", uint8(5), true)
 
-	// Only values strongly typed as strings will resolve properly.
-	suite.ExpectUint8("EMPTY", uint8(0), false)
-	suite.ExpectUint8("STRING", uint8(0), false)
-	suite.ExpectUint8("STRING_SLICE", uint8(0), false)
-	suite.ExpectUint8("INT", uint8(0), false)
+	// Values must fit in 8 bits.
 	suite.ExpectUint8("LARGE_INT", uint8(0), false)
 	suite.ExpectUint8("NEGATIVE", uint8(0), false)
 	suite.ExpectUint8("FLOAT64", uint8(0), false)
+	suite.ExpectUint8("EMPTY", uint8(0), false)
+	suite.ExpectUint8("STRING", uint8(0), false)
+	suite.ExpectUint8("STRING_SLICE", uint8(0), false)
 }
 
 func (suite MapSuite) TestUint16() {
 	suite.ExpectUint16("NOT_FOUND", uint16(0), false)
 	suite.ExpectUint16("UINT16", uint16(160), true)
+	suite.ExpectUint16("INT", uint16(5), true)
+	suite.ExpectUint16("CUSTOM_INT", uint16(8080), true)
+	suite.ExpectUint16("TEXT_INT", uint16(8080), true)
 
-	// Only values strongly typed as strings will resolve properly.
-	suite.ExpectUint16("EMPTY", uint16(0), false)
-	suite.ExpectUint16("STRING", uint16(0), false)
-	suite.ExpectUint16("STRING_SLICE", uint16(0), false)
-	suite.ExpectUint16("INT", uint16(0), false)
 	suite.ExpectUint16("LARGE_INT", uint16(0), false)
 	suite.ExpectUint16("NEGATIVE", uint16(0), false)
 	suite.ExpectUint16("FLOAT64", uint16(0), false)
+	suite.ExpectUint16("EMPTY", uint16(0), false)
+	suite.ExpectUint16("STRING", uint16(0), false)
+	suite.ExpectUint16("STRING_SLICE", uint16(0), false)
 }
 
 func (suite MapSuite) TestUint32() {
 	suite.ExpectUint32("NOT_FOUND", uint32(0), false)
 	suite.ExpectUint32("UINT32", uint32(320), true)
+	suite.ExpectUint32("INT", uint32(5), true)
+	suite.ExpectUint32("LARGE_INT", uint32(5300123), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectUint32("NEGATIVE", uint32(0), false)
+	suite.ExpectUint32("FLOAT64", uint32(0), false)
 	suite.ExpectUint32("EMPTY", uint32(0), false)
 	suite.ExpectUint32("STRING", uint32(0), false)
 	suite.ExpectUint32("STRING_SLICE", uint32(0), false)
-	suite.ExpectUint32("INT", uint32(0), false)
-	suite.ExpectUint32("LARGE_INT", uint32(0), false)
-	suite.ExpectUint32("NEGATIVE", uint32(0), false)
-	suite.ExpectUint32("FLOAT64", uint32(0), false)
 }
 
 func (suite MapSuite) TestUint64() {
 	suite.ExpectUint64("NOT_FOUND", uint64(0), false)
 	suite.ExpectUint64("UINT64", uint64(640), true)
+	suite.ExpectUint64("INT", uint64(5), true)
+	suite.ExpectUint64("LARGE_INT", uint64(5300123), true)
 
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectUint64("NEGATIVE", uint64(0), false)
+	suite.ExpectUint64("FLOAT64", uint64(0), false)
 	suite.ExpectUint64("EMPTY", uint64(0), false)
 	suite.ExpectUint64("STRING", uint64(0), false)
 	suite.ExpectUint64("STRING_SLICE", uint64(0), false)
-	suite.ExpectUint64("INT", uint64(0), false)
-	suite.ExpectUint64("LARGE_INT", uint64(0), false)
-	suite.ExpectUint64("NEGATIVE", uint64(0), false)
-	suite.ExpectUint64("FLOAT64", uint64(0), false)
 }
 
 func (suite MapSuite) TestBool() {
 	suite.ExpectBool("NOT_FOUND", false, false)
 	suite.ExpectBool("BOOL_TRUE", true, true)
 	suite.ExpectBool("BOOL_FALSE", false, true)
+	suite.ExpectBool("TEXT_BOOL", true, true)
 
-	// Only values strongly typed as strings will resolve properly.
+	// Numbers aren't booleans, even 0 and 1.
 	suite.ExpectBool("EMPTY", false, false)
 	suite.ExpectBool("STRING", false, false)
 	suite.ExpectBool("STRING_SLICE", false, false)
@@ -230,31 +290,52 @@ func (suite MapSuite) TestBool() {
 func (suite MapSuite) TestFloat32() {
 	suite.ExpectFloat32("NOT_FOUND", float32(0), false)
 	suite.ExpectFloat32("FLOAT32", float32(2.89), true)
-
-	// Only values strongly typed as strings will resolve properly.
+	suite.ExpectFloat32("UINT8", float32(80), true)
+	suite.ExpectFloat32("NEGATIVE", float32(-3), true)
+	suite.ExpectFloat32("WHOLE_FLOAT", float32(1200), true)
+	suite.ExpectFloat32("TEXT_FLOAT", float32(2.5), true)
+	suite.ExpectFloat32("FLOAT64", float32(5.430), true)
+	suite.ExpectFloat32("LARGE_FLOAT", float32(5300123.430), true)
 	suite.ExpectFloat32("EMPTY", float32(0), false)
 	suite.ExpectFloat32("STRING", float32(0), false)
 	suite.ExpectFloat32("STRING_SLICE", float32(0), false)
-	suite.ExpectFloat32("UINT8", float32(0), false)
-	suite.ExpectFloat32("FLOAT64", float32(0), false)
+
+	// Values are rounded like the environment's, but they still need to fit in a float32.
+	huge := configify.Map(configify.Values{"HUGE": 1e40, "HUGE_TEXT": "1e40", "INFINITE": math.Inf(1)})
+	_, ok := huge.Float32("HUGE")
+	suite.False(ok)
+	_, ok = huge.Float32("HUGE_TEXT")
+	suite.False(ok)
+	infinite, ok := huge.Float32("INFINITE")
+	suite.True(ok)
+	suite.True(math.IsInf(float64(infinite), 1))
 }
 
 func (suite MapSuite) TestFloat64() {
 	suite.ExpectFloat64("NOT_FOUND", float64(0), false)
 	suite.ExpectFloat64("FLOAT64", 5.430, true)
+	suite.ExpectFloat64("FLOAT32", float64(float32(2.89)), true)
+	suite.ExpectFloat64("UINT8", float64(80), true)
+	suite.ExpectFloat64("LARGE_INT", float64(5300123), true)
+	suite.ExpectFloat64("TEXT_FLOAT", 2.5, true)
+	suite.ExpectFloat64("TEXT_INT", 0, false)
 
-	// Only values strongly typed as strings will resolve properly.
 	suite.ExpectFloat64("EMPTY", float64(0), false)
 	suite.ExpectFloat64("STRING", float64(0), false)
 	suite.ExpectFloat64("STRING_SLICE", float64(0), false)
-	suite.ExpectFloat64("UINT8", float64(0), false)
+	suite.ExpectFloat64("DURATION", float64(0), false)
+
+	// A float64 can't represent every int64 exactly.
+	suite.Source = configify.Map(configify.Values{"PRECISE": int64(math.MaxInt64 - 1)})
+	suite.ExpectFloat64("PRECISE", float64(0), false)
 }
 
 func (suite MapSuite) TestDuration() {
 	suite.ExpectDuration("NOT_FOUND", time.Duration(0), false)
 	suite.ExpectDuration("DURATION", 5*time.Minute, true)
+	suite.ExpectDuration("TEXT_DURATION", 5*time.Minute+3*time.Second, true)
 
-	// Only values strongly typed as strings will resolve properly.
+	// Plain numbers don't have a unit, so they're not durations.
 	suite.ExpectDuration("EMPTY", time.Duration(0), false)
 	suite.ExpectDuration("STRING", time.Duration(0), false)
 	suite.ExpectDuration("STRING_SLICE", time.Duration(0), false)
@@ -267,8 +348,8 @@ func (suite MapSuite) TestDuration() {
 func (suite MapSuite) TestTime() {
 	suite.ExpectTime("NOT_FOUND", time.Time{}, false)
 	suite.ExpectTime("TIME", time.Date(2019, time.December, 25, 8, 33, 40, 0, time.UTC), true)
+	suite.ExpectTime("TEXT_TIME", time.Date(2019, time.December, 25, 0, 0, 0, 0, time.UTC), true)
 
-	// Only values strongly typed as strings will resolve properly.
 	suite.ExpectTime("EMPTY", time.Time{}, false)
 	suite.ExpectTime("STRING", time.Time{}, false)
 	suite.ExpectTime("STRING_SLICE", time.Time{}, false)
@@ -279,14 +360,100 @@ func (suite MapSuite) TestTime() {
 	suite.ExpectTime("DURATION", time.Time{}, false)
 }
 
+func (suite MapSuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "Map should be enumerable")
+
+	suite.Equal([]string{"STRING", "STRING_SLICE", "STRING_SLICE_EMPTY", "STRING_SLICE_NIL", "STRING_SPACE"}, enumerable.Keys("STRING"))
+	suite.Equal([]string{"UINT", "UINT16", "UINT32", "UINT64", "UINT8"}, enumerable.Keys("UINT"))
+	suite.Empty(enumerable.Keys("NOT_FOUND"))
+	suite.Len(enumerable.Keys(""), 38)
+}
+
+func (suite MapSuite) TestNested() {
+	suite.Source = configify.Map(configify.Values{
+		"HTTP": configify.Values{
+			"HOST": "localhost",
+			"PORT": 8080,
+			"TLS": map[string]interface{}{
+				"ENABLED": true,
+			},
+		},
+		"DB":      configify.Values{"HOST": "nested"},
+		"DB_HOST": "flat",
+	})
+	suite.ExpectString("HTTP_HOST", "localhost", true)
+	suite.ExpectUint16("HTTP_PORT", 8080, true)
+	suite.ExpectBool("HTTP_TLS_ENABLED", true, true)
+	suite.ExpectString("HTTP", "", false)
+	suite.ExpectString("HTTP_TLS", "", false)
+
+	// Flat keys win when they collide with nested ones.
+	suite.ExpectString("DB_HOST", "flat", true)
+
+	enumerable := suite.Source.(configify.Enumerable)
+	suite.Equal([]string{"HTTP_HOST", "HTTP_PORT", "HTTP_TLS_ENABLED"}, enumerable.Keys("HTTP"))
+
+	// Nested keys are joined using the namespace delimiter.
+	suite.Source = configify.Map(configify.Values{
+		"HTTP": configify.Values{"PORT": 8080},
+	}, configify.NamespaceDelim("."))
+	suite.ExpectInt("HTTP.PORT", 8080, true)
+	suite.ExpectInt("HTTP_PORT", 0, false)
+}
+
+func (suite MapSuite) TestNamespace() {
+	suite.Source = configify.Map(configify.Values{
+		"APP":  configify.Values{"PORT": 8080},
+		"PORT": 9000,
+	}, configify.Namespace("APP"))
+	suite.Equal("APP", suite.Source.Options().Namespace.Name)
+	suite.ExpectInt("PORT", 8080, true)
+	suite.Equal([]string{"PORT"}, suite.Source.(configify.Enumerable).Keys(""))
+	suite.Equal(configify.Origin{Source: "map", Key: "APP_PORT", Found: true}, configify.Explain(suite.Source, "PORT"))
+}
+
+func (suite MapSuite) TestNestedDefaults() {
+	defaults := configify.Defaults(configify.Values{
+		"HTTP": configify.Values{"PORT": 8080},
+	})
+
+	// The delimiter applies to the defaults regardless of the order of the options.
+	for _, opts := range [][]configify.Option{
+		{configify.NamespaceDelim("."), defaults},
+		{defaults, configify.NamespaceDelim(".")},
+	} {
+		suite.Source = configify.Environment(append(opts, configify.Namespace("MAP_DEFAULTS"))...)
+		suite.ExpectInt("HTTP.PORT", 8080, true)
+		suite.ExpectInt("HTTP_PORT", 0, false)
+	}
+
+	config := struct {
+		HTTP struct {
+			Port uint16
+			TLS  struct {
+				Enabled bool
+			}
+		}
+	}{}
+	source := configify.Environment(configify.Namespace("MAP_DEFAULTS"), configify.Defaults(configify.Values{
+		"HTTP": configify.Values{
+			"PORT": 8080,
+			"TLS":  configify.Values{"ENABLED": true},
+		},
+	}))
+	configify.NewBinder(source).Bind(&config)
+	suite.Equal(uint16(8080), config.HTTP.Port)
+	suite.True(config.HTTP.TLS.Enabled)
+}
+
 func ExampleMap() {
-	// Since you have full control over the values, be sure to strongly-type them
-	// to the types you expect to get out. For instance if you plan to grab the
-	// key "FOO" as a uint8, then put it in the values map as a uint8, not a plain
-	// old int.
+	// Values convert to any compatible type, so you can grab the plain old int
+	// 1234 as a uint16 or the string "20s" as a duration. Values that don't fit
+	// (e.g. 300 as a uint8) are not ok, just like in the environment.
 	config := configify.Map(configify.Values{
 		"HOST":    "localhost",
-		"PORT":    uint16(1234),
+		"PORT":    1234,
 		"TIMEOUT": 20 * time.Second,
 		"THINGS":  []string{"foo", "bar", "baz"},
 	})
@@ -9,6 +9,9 @@ import (
 // Massage standardizes how we try to convert values from a source into raw values to
 // feed to your program.
 type Massage struct {
+	// Lenient allows integer parsing to truncate decimal values (e.g. "12.9" becomes 12) rather
+	// than treating them as invalid integers.
+	Lenient bool
 }
 
 // StringToSlice splits the value by commas, stripping any spaces in the tokens.
@@ -23,20 +26,61 @@ func (Massage) StringToSlice(value string) ([]string, bool) {
 	return slice, true
 }
 
-// StringToInt64 parses the value as an integer. This will strip out any commas and
-// decimal info before performing the actual parse.
+// StringToMap parses comma-separated "key=value" pairs (e.g. "team=core, tier=gold"), stripping
+// any spaces around the keys and values. Every pair must contain an "=" to be valid.
+func (m Massage) StringToMap(value string) (map[string]string, bool) {
+	values := map[string]string{}
+	if strings.TrimSpace(value) == "" {
+		return values, true
+	}
+
+	pairs, _ := m.StringToSlice(value)
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, false
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, true
+}
+
+// StringToInt64 parses the value as an integer. This will strip out any commas before
+// performing the actual parse.
 func (m Massage) StringToInt64(value string) (int64, bool) {
-	number, err := strconv.ParseInt(m.normalizeInteger(value), 10, 64)
+	return m.StringToIntSize(value, 64)
+}
+
+// StringToIntSize parses the value as an integer that must fit in the given number of bits (e.g. 8
+// for an int8 or strconv.IntSize for an int). Values outside of that range are not "ok" rather than
+// silently wrapping around.
+func (m Massage) StringToIntSize(value string, bitSize int) (int64, bool) {
+	normalized, ok := m.normalizeInteger(value)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseInt(normalized, 10, bitSize)
 	if err != nil {
 		return 0, false
 	}
 	return number, true
 }
 
-// StringToUint64 parses the value as an integer. This will strip out any commas and
-// decimal info before performing the actual parse.
+// StringToUint64 parses the value as an unsigned integer. This will strip out any commas
+// before performing the actual parse.
 func (m Massage) StringToUint64(value string) (uint64, bool) {
-	number, err := strconv.ParseUint(m.normalizeInteger(value), 10, 64)
+	return m.StringToUintSize(value, 64)
+}
+
+// StringToUintSize parses the value as an unsigned integer that must fit in the given number of
+// bits (e.g. 16 for a uint16). Values outside of that range are not "ok" rather than silently
+// wrapping around.
+func (m Massage) StringToUintSize(value string, bitSize int) (uint64, bool) {
+	normalized, ok := m.normalizeInteger(value)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseUint(normalized, 10, bitSize)
 	if err != nil {
 		return 0, false
 	}
@@ -45,7 +89,14 @@ func (m Massage) StringToUint64(value string) (uint64, bool) {
 
 // StringToFloat64 parses the value as a floating point number.
 func (m Massage) StringToFloat64(value string) (float64, bool) {
-	number, err := strconv.ParseFloat(value, 64)
+	return m.StringToFloatSize(value, 64)
+}
+
+// StringToFloatSize parses the value as a floating point number that must fit in the given number
+// of bits (e.g. 32 for a float32). The value is rounded to the nearest number of that size, but
+// values too large to fit are not "ok" rather than silently becoming infinity.
+func (m Massage) StringToFloatSize(value string, bitSize int) (float64, bool) {
+	number, err := strconv.ParseFloat(value, bitSize)
 	if err != nil {
 		return 0, false
 	}
@@ -95,15 +146,25 @@ func (m Massage) StringToTime(value string) (time.Time, bool) {
 	return t, true
 }
 
-// normalizeInteger strips all commas and decimal points so you get the raw integer
-// encoded in this string.
-func (m Massage) normalizeInteger(value string) string {
+// normalizeInteger strips all commas so you get the raw integer encoded in this string. Decimal
+// values are only integers if there's nothing but zeros after the decimal point (e.g. "12.0"). In
+// Lenient mode, we'll truncate any decimal value instead (e.g. "12.9" becomes "12").
+func (m Massage) normalizeInteger(value string) (string, bool) {
+	value = strings.ReplaceAll(value, ",", "")
 	decimalPos := strings.IndexRune(value, '.')
+	if decimalPos < 0 {
+		return value, true
+	}
 	if decimalPos == 0 {
-		return ""
+		return "", false
+	}
+
+	fraction := value[decimalPos+1:]
+	if !m.Lenient && strings.Trim(fraction, "0") != "" {
+		return "", false
 	}
-	if decimalPos > 0 {
-		value = value[:decimalPos]
+	if strings.Trim(fraction, "0123456789") != "" {
+		return "", false
 	}
-	return strings.ReplaceAll(value, string(','), "")
+	return value[:decimalPos], true
 }
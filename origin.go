@@ -0,0 +1,102 @@
+package configify
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Origin describes where a source found the value for a key, so you can tell whether a value came
+// from the environment, a file, or some fallback.
+type Origin struct {
+	// Source is the name of the source that supplied the value (e.g. "env", "dotenv:.env", "consul",
+	// "map", or "default" for default values in struct tags). It's empty when no source had a value.
+	Source string
+	// Key is the fully namespace-qualified key that the value was found under (or would have been).
+	Key string
+	// Found indicates whether any source actually had a value for the key.
+	Found bool
+	// Default indicates that the value came from a fallback rather than the source you asked: either
+	// the source's Defaults option or a default value in a struct tag.
+	Default bool
+}
+
+// Explainer defines a Source that can tell you where it found the value for a key. All of the sources
+// in this package support this, so you'll typically just call the Explain() function.
+type Explainer interface {
+	Source
+	Explain(key string) Origin
+}
+
+// Explain describes where the source found the value for the given key. Sources that don't implement
+// Explainer are reported using their Go type name if they have a string value for the key.
+func Explain(source Source, key string) Origin {
+	if source == nil {
+		return Origin{Key: key}
+	}
+	if explainer, ok := source.(Explainer); ok {
+		return explainer.Explain(key)
+	}
+
+	qualifiedKey := source.Options().Namespace.Qualify(key)
+	if _, ok := source.String(key); ok {
+		return Origin{Source: fmt.Sprintf("%T", source), Key: qualifiedKey, Found: true}
+	}
+	return Origin{Key: qualifiedKey}
+}
+
+// explainDefaults describes the origin of a value that a source had to look up in its Defaults. The
+// key is always the one qualified by the original source's namespace since that's what you'd set to
+// override the default value.
+func explainDefaults(options Options, key string) Origin {
+	origin := Explain(options.Defaults, key)
+	origin.Key = options.Namespace.Qualify(key)
+	origin.Default = origin.Found
+	return origin
+}
+
+// FieldReport describes how the binder populated a single struct field.
+type FieldReport struct {
+	// Field is the Go-style path to the struct field (e.g. "HTTP.Port").
+	Field string
+	// Key is the fully namespace-qualified config key for the field (e.g. "MYAPP_HTTP_PORT").
+	Key string
+	// Source is the name of the source that supplied the value. It's empty when no source had a
+	// value for the field, so it kept whatever value it had before binding.
+	Source string
+	// Default indicates that the value came from a fallback rather than the source itself: either
+	// the source's Defaults option or the default value in the field's struct tag.
+	Default bool
+	// Value is the field's value after binding, formatted as a string.
+	Value string
+}
+
+// Report describes the effective configuration after binding: one entry for every field that maps
+// to a config key, in the order the fields are defined. Its String() output is a table you can log
+// at startup.
+type Report []FieldReport
+
+func (r Report) String() string {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "FIELD\tKEY\tSOURCE\tVALUE")
+	for _, field := range r {
+		source := field.Source
+		switch {
+		case source == "":
+			source = "-"
+		case field.Default && source != "default":
+			source += " (default)"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", field.Field, field.Key, source, field.Value)
+	}
+	_ = writer.Flush()
+	return builder.String()
+}
+
+// add records the outcome of binding a single field.
+func (r *Report) add(field FieldReport) {
+	if r != nil {
+		*r = append(*r, field)
+	}
+}
@@ -0,0 +1,168 @@
+package configify_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOriginSuite(t *testing.T) {
+	suite.Run(t, new(OriginSuite))
+}
+
+type OriginSuite struct {
+	suite.Suite
+}
+
+func (suite *OriginSuite) SetupTest() {
+	_ = os.Setenv("ORIGIN_HOST", "example.com")
+	_ = os.Setenv("ORIGIN_PORT", "8080")
+	_ = os.Setenv("ORIGIN_LIMIT_TENANT_A", "5")
+	_ = os.Unsetenv("ORIGIN_TIMEOUT")
+}
+
+func (suite OriginSuite) environment() configify.Source {
+	return configify.Environment(
+		configify.Namespace("ORIGIN"),
+		configify.Defaults(configify.Values{
+			"TIMEOUT": time.Minute,
+			"PORT":    9000,
+		}))
+}
+
+func (suite OriginSuite) TestExplain() {
+	env := suite.environment()
+	suite.Equal(configify.Origin{Source: "env", Key: "ORIGIN_HOST", Found: true}, configify.Explain(env, "HOST"))
+	suite.Equal(configify.Origin{Source: "env", Key: "ORIGIN_PORT", Found: true}, configify.Explain(env, "PORT"))
+	suite.Equal(configify.Origin{Source: "map", Key: "ORIGIN_TIMEOUT", Found: true, Default: true}, configify.Explain(env, "TIMEOUT"))
+	suite.Equal(configify.Origin{Key: "ORIGIN_NOT_FOUND"}, configify.Explain(env, "NOT_FOUND"))
+
+	suite.Equal(configify.Origin{Source: "map", Key: "A", Found: true}, configify.Explain(configify.Map(configify.Values{"A": 1}), "A"))
+	suite.Equal(configify.Origin{Key: "A"}, configify.Explain(configify.Empty(), "A"))
+	suite.Equal(configify.Origin{Key: "A"}, configify.Explain(nil, "A"))
+}
+
+func (suite OriginSuite) TestExplainLayers() {
+	path := filepath.Join(suite.T().TempDir(), ".env")
+	suite.Require().NoError(os.WriteFile(path, []byte("ORIGIN_DATABASE=postgres\n"), 0600))
+	dotEnv, err := configify.DotEnv(path, configify.Namespace("ORIGIN"))
+	suite.Require().NoError(err)
+
+	source := configify.Poll(configify.Chain(suite.environment(), dotEnv), []string{"HOST"})
+	suite.Equal(configify.Origin{Source: "env", Key: "ORIGIN_HOST", Found: true}, configify.Explain(source, "HOST"))
+	suite.Equal(configify.Origin{Source: "dotenv:" + path, Key: "ORIGIN_DATABASE", Found: true}, configify.Explain(source, "DATABASE"))
+	suite.Equal(configify.Origin{Source: "map", Key: "ORIGIN_TIMEOUT", Found: true, Default: true}, configify.Explain(source, "TIMEOUT"))
+	suite.False(configify.Explain(source, "NOT_FOUND").Found)
+
+	// A strict chain never falls through to a lower priority source when a higher one has an invalid
+	// value, so that's the source we should blame.
+	_ = os.Setenv("ORIGIN_WORKERS", "lots")
+	defer os.Unsetenv("ORIGIN_WORKERS")
+	source = configify.StrictChain(suite.environment(), configify.Map(configify.Values{"WORKERS": 4}))
+	suite.Equal(configify.Origin{Source: "env", Key: "ORIGIN_WORKERS", Found: true}, configify.Explain(source, "WORKERS"))
+}
+
+func (suite OriginSuite) TestBindReportLayers() {
+	_ = os.Setenv("ORIGIN_WORKERS", "lots")
+	defer os.Unsetenv("ORIGIN_WORKERS")
+	config := struct {
+		Host    string
+		Workers int
+	}{}
+
+	// A regular chain skips the invalid value, so the report should credit the source we actually used.
+	source := configify.Chain(suite.environment(), configify.Map(configify.Values{"WORKERS": 4}))
+	report, err := configify.NewBinder(source).BindReport(&config)
+	suite.Require().NoError(err)
+	suite.Equal(4, config.Workers)
+	suite.Equal(configify.Report{
+		{Field: "Host", Key: "ORIGIN_HOST", Source: "env", Value: "example.com"},
+		{Field: "Workers", Key: "ORIGIN_WORKERS", Source: "map", Value: "4"},
+	}, report)
+
+	source = configify.StrictChain(suite.environment(), configify.Map(configify.Values{"WORKERS": 4}))
+	report, err = configify.NewBinder(source).BindReport(&config)
+	suite.Error(err)
+	suite.Equal(configify.FieldReport{Field: "Workers", Key: "ORIGIN_WORKERS", Value: "4"}, report[1])
+}
+
+func (suite OriginSuite) TestExplainNonExplainer() {
+	source := configifytest.NewMockSource(func(source *configifytest.MockSource) {
+		source.On("String", "HOST").Return("localhost", true)
+	})
+	suite.Equal(configify.Origin{Source: "*configifytest.MockSource", Key: "HOST", Found: true}, configify.Explain(source, "HOST"))
+	suite.Equal(configify.Origin{Key: "PORT"}, configify.Explain(source, "PORT"))
+}
+
+func (suite OriginSuite) TestBindReport() {
+	type TLSConfig struct {
+		CertFile string
+	}
+	config := struct {
+		Host    string
+		Port    int
+		Timeout time.Duration
+		Retries int `default:"3"`
+		Debug   bool
+		Limit   map[string]int
+		Nested  struct {
+			Name string `default:"nested"`
+		}
+		TLS *TLSConfig
+		// Unexported fields can't be bound, so they shouldn't show up in the report either.
+		hidden string
+	}{Debug: true}
+	_ = os.Setenv("ORIGIN_HIDDEN", "hidden")
+	defer os.Unsetenv("ORIGIN_HIDDEN")
+
+	report, err := configify.NewBinder(suite.environment()).BindReport(&config)
+	suite.Require().NoError(err)
+	suite.Equal(configify.Report{
+		{Field: "Host", Key: "ORIGIN_HOST", Source: "env", Value: "example.com"},
+		{Field: "Port", Key: "ORIGIN_PORT", Source: "env", Value: "8080"},
+		{Field: "Timeout", Key: "ORIGIN_TIMEOUT", Source: "map", Default: true, Value: "1m0s"},
+		{Field: "Retries", Key: "ORIGIN_RETRIES", Source: "default", Default: true, Value: "3"},
+		{Field: "Debug", Key: "ORIGIN_DEBUG", Value: "true"},
+		{Field: "Limit", Key: "ORIGIN_LIMIT", Source: "env", Value: "map[TENANT_A:5]"},
+		{Field: "Nested.Name", Key: "ORIGIN_NESTED_NAME", Source: "default", Default: true, Value: "nested"},
+	}, report)
+
+	// The pointer is only allocated (and reported) when there's something to put in it.
+	_ = os.Setenv("ORIGIN_TLS_CERT_FILE", "cert.pem")
+	defer os.Unsetenv("ORIGIN_TLS_CERT_FILE")
+	config.TLS = nil
+	report, err = configify.NewBinder(suite.environment()).BindReport(&config)
+	suite.Require().NoError(err)
+	suite.Equal(configify.FieldReport{Field: "TLS.CertFile", Key: "ORIGIN_TLS_CERT_FILE", Source: "env", Value: "cert.pem"}, report[len(report)-1])
+
+	report, err = configify.NewBinder(nil).BindReport(&config)
+	suite.NoError(err)
+	suite.Empty(report)
+}
+
+func ExampleReport() {
+	_ = os.Setenv("EXAMPLE_HOST", "example.com")
+	config := struct {
+		Host    string
+		Port    int `default:"8080"`
+		Timeout time.Duration
+		Debug   bool
+	}{}
+	source := configify.Environment(
+		configify.Namespace("EXAMPLE"),
+		configify.Defaults(configify.Values{"TIMEOUT": 30 * time.Second}))
+
+	report, _ := configify.NewBinder(source).BindReport(&config)
+	fmt.Print(report)
+	// Output: FIELD    KEY              SOURCE         VALUE
+	// Host     EXAMPLE_HOST     env            example.com
+	// Port     EXAMPLE_PORT     default        8080
+	// Timeout  EXAMPLE_TIMEOUT  map (default)  30s
+	// Debug    EXAMPLE_DEBUG    -              false
+}
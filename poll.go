@@ -0,0 +1,151 @@
+package configify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Poll wraps any source, making it a SourceWatcher that periodically checks whether the values for
+// the given keys have changed. This is useful for sources like the environment or a mounted file that
+// have no built-in way of telling you when they've been modified. Lookups are passed straight through
+// to the underlying source; the polling only determines when your Watch callbacks fire. If you don't
+// supply any keys and the source is Enumerable, we watch every key that it has.
+//
+// The following options control the polling behavior:
+//
+//   - RefreshInterval: How often we snapshot the source to look for changes. Defaults to 30 seconds.
+//   - Context: Cancel this context to stop polling.
+//
+// Polling only begins once you register your first Watch callback.
+func Poll(source Source, keys []string, opts ...Option) SourceWatcher {
+	options := apply(opts, &Options{
+		Context:         context.Background(),
+		RefreshInterval: 30 * time.Second,
+	})
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+
+	poller := &pollSource{
+		Source:      source,
+		keys:        keys,
+		pollOptions: *options,
+	}
+	poller.snapshot = poller.takeSnapshot()
+	return poller
+}
+
+type pollSource struct {
+	Source
+	keys        []string
+	pollOptions Options
+	mutex       sync.Mutex
+	snapshot    map[string]pollValue
+	callbacks   []func(Source)
+	watchOnce   sync.Once
+}
+
+// reloader is implemented by sources that cache their values (e.g. Directory) so that they can
+// re-read them right before we take each snapshot.
+type reloader interface {
+	reload()
+}
+
+// pollValue captures the state of a single key at the time we took a snapshot.
+type pollValue struct {
+	value string
+	ok    bool
+}
+
+// Watch registers a callback that fires whenever we detect that at least one of the polled keys has
+// a different value than it did on the previous check. The first call starts the polling loop.
+func (p *pollSource) Watch(callback func(source Source)) {
+	p.mutex.Lock()
+	p.callbacks = append(p.callbacks, callback)
+	p.mutex.Unlock()
+
+	p.watchOnce.Do(func() {
+		go p.poll()
+	})
+}
+
+func (p *pollSource) poll() {
+	ticker := time.NewTicker(p.pollOptions.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.pollOptions.Context.Done():
+			return
+		case <-ticker.C:
+			p.refresh()
+		}
+	}
+}
+
+// refresh takes a new snapshot of the source and notifies everyone if anything changed since last time.
+func (p *pollSource) refresh() {
+	if cached, ok := p.Source.(reloader); ok {
+		cached.reload()
+	}
+	snapshot := p.takeSnapshot()
+
+	p.mutex.Lock()
+	changed := p.changedKeys(snapshot)
+	p.snapshot = snapshot
+	callbacks := append([]func(Source){}, p.callbacks...)
+	p.mutex.Unlock()
+
+	if len(changed) == 0 || p.pollOptions.Context.Err() != nil {
+		return
+	}
+	for _, callback := range callbacks {
+		callback(p)
+	}
+}
+
+func (p *pollSource) takeSnapshot() map[string]pollValue {
+	keys := p.keys
+	if len(keys) == 0 {
+		// No explicit keys, so watch everything the source can tell us about.
+		keys = p.Keys("")
+	}
+
+	snapshot := make(map[string]pollValue, len(keys))
+	for _, key := range keys {
+		value, ok := p.Source.String(key)
+		snapshot[key] = pollValue{value: value, ok: ok}
+	}
+	return snapshot
+}
+
+// changedKeys compares the given snapshot to the most recent one, returning the keys whose values
+// were added, removed, or modified.
+func (p *pollSource) changedKeys(snapshot map[string]pollValue) []string {
+	var changed []string
+	for key, value := range snapshot {
+		if p.snapshot[key] != value {
+			changed = append(changed, key)
+		}
+	}
+	for key, value := range p.snapshot {
+		if _, ok := snapshot[key]; !ok && value.ok {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+// Explain reports the origin of the value in the underlying source.
+func (p *pollSource) Explain(key string) Origin {
+	return Explain(p.Source, key)
+}
+
+// Keys lists the keys of the underlying source if it's Enumerable. Otherwise, there are none.
+func (p *pollSource) Keys(prefix string) []string {
+	if enumerable, ok := p.Source.(Enumerable); ok {
+		return enumerable.Keys(prefix)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package configify_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestPollSuite(t *testing.T) {
+	suite.Run(t, new(PollSuite))
+}
+
+type PollSuite struct {
+	configifytest.SourceSuite
+}
+
+func (suite *PollSuite) SetupTest() {
+	_ = os.Setenv("POLL_STRING", "foo")
+	_ = os.Setenv("POLL_INT", "5")
+	_ = os.Unsetenv("POLL_NEW")
+	_ = os.Unsetenv("POLL_IGNORED")
+}
+
+func (suite PollSuite) TestPassThrough() {
+	env := configify.Environment(configify.Namespace("POLL"))
+	suite.Source = configify.Poll(env, []string{"STRING"})
+
+	suite.Equal(env.Options(), suite.Source.Options())
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectString("NOT_FOUND", "", false)
+}
+
+func (suite PollSuite) TestWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := configify.Poll(
+		configify.Environment(configify.Namespace("POLL")),
+		[]string{"STRING", "INT", "NEW"},
+		configify.Context(ctx),
+		configify.RefreshInterval(10*time.Millisecond))
+	suite.Source = source
+
+	changes := make(chan configify.Source, 10)
+	source.Watch(func(source configify.Source) { changes <- source })
+
+	// Nothing changed or only keys we aren't polling changed.
+	_ = os.Setenv("POLL_IGNORED", "whatever")
+	suite.expectNoChange(changes)
+
+	// Modified values
+	_ = os.Setenv("POLL_STRING", "bar")
+	suite.Equal(source, suite.receive(changes))
+	suite.ExpectString("STRING", "bar", true)
+	suite.expectNoChange(changes)
+
+	// Added values
+	_ = os.Setenv("POLL_NEW", "hello")
+	suite.Equal(source, suite.receive(changes))
+
+	// Removed values
+	_ = os.Unsetenv("POLL_INT")
+	suite.Equal(source, suite.receive(changes))
+
+	// Nobody hears about changes once the context is done.
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	_ = os.Setenv("POLL_STRING", "baz")
+	suite.expectNoChange(changes)
+}
+
+func (suite PollSuite) TestWatchAllKeys() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// No explicit keys, so we watch everything in the namespace.
+	source := configify.Poll(
+		configify.Environment(configify.Namespace("POLL")),
+		nil,
+		configify.Context(ctx),
+		configify.RefreshInterval(10*time.Millisecond))
+	suite.Contains(source.(configify.Enumerable).Keys(""), "STRING")
+
+	changes := make(chan configify.Source, 10)
+	source.Watch(func(source configify.Source) { changes <- source })
+
+	_ = os.Setenv("POLL_NEW", "hello")
+	suite.Equal(source, suite.receive(changes))
+
+	_ = os.Unsetenv("POLL_NEW")
+	suite.Equal(source, suite.receive(changes))
+	suite.expectNoChange(changes)
+}
+
+func (suite PollSuite) receive(changes chan configify.Source) configify.Source {
+	select {
+	case source := <-changes:
+		return source
+	case <-time.After(time.Second):
+		suite.Fail("Timed out waiting for change notification")
+		return nil
+	}
+}
+
+func (suite PollSuite) expectNoChange(changes chan configify.Source) {
+	select {
+	case <-changes:
+		suite.Fail("Should not have received a change notification")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,69 @@
+package configify
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+// redacted is what we show instead of the actual value of a secret.
+const redacted = "[REDACTED]"
+
+var typeSecret = reflect.TypeOf(Secret(""))
+
+// Secret is a string config value, such as a password or API key, that should never show up in your
+// logs. You can bind it just like any other string, but printing it (even with %#v), marshaling it
+// to JSON, or logging it using log/slog only ever shows "[REDACTED]". Call Value() when you actually
+// need the real thing.
+//
+//	type DatabaseConfig struct {
+//		Host     string
+//		Password configify.Secret
+//	}
+type Secret string
+
+// Value returns the actual, unredacted secret.
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String returns "[REDACTED]" rather than the actual secret. Empty secrets are still empty so that
+// you can tell when a secret was never set.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString redacts the secret when printing it using the %#v verb.
+func (s Secret) GoString() string {
+	return fmt.Sprintf("configify.Secret(%q)", s.String())
+}
+
+// MarshalJSON redacts the secret when you marshal your config to JSON.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// LogValue redacts the secret when you log it using log/slog.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// redactValue masks the raw value of a secret field when we include it in errors and reports.
+func redactValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// isSecretType indicates whether the type is a Secret (or a pointer/slice of them).
+func isSecretType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t == typeSecret
+}
@@ -0,0 +1,148 @@
+package configify_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/robsignorelli/configify"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSecretSuite(t *testing.T) {
+	suite.Run(t, new(SecretSuite))
+}
+
+type SecretSuite struct {
+	suite.Suite
+}
+
+func (suite SecretSuite) TestRedacted() {
+	secret := configify.Secret("hunter2")
+	suite.Equal("hunter2", secret.Value())
+	suite.Equal("[REDACTED]", secret.String())
+	suite.Equal("[REDACTED]", fmt.Sprintf("%v", secret))
+	suite.Equal("[REDACTED]", fmt.Sprintf("%s", secret))
+	suite.Equal(`"[REDACTED]"`, fmt.Sprintf("%q", secret))
+	suite.Equal(`configify.Secret("[REDACTED]")`, fmt.Sprintf("%#v", secret))
+
+	config := struct {
+		User     string
+		Password configify.Secret
+	}{User: "admin", Password: secret}
+	suite.Equal("{admin [REDACTED]}", fmt.Sprintf("%v", config))
+	suite.NotContains(fmt.Sprintf("%+v", config), "hunter2")
+	suite.NotContains(fmt.Sprintf("%#v", config), "hunter2")
+
+	data, err := json.Marshal(config)
+	suite.Require().NoError(err)
+	suite.Equal(`{"User":"admin","Password":"[REDACTED]"}`, string(data))
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+	logger.Info("connecting", "password", secret)
+	suite.Equal("level=INFO msg=connecting password=[REDACTED]\n", buffer.String())
+
+	// You can still tell when a secret wasn't set.
+	suite.Equal("", configify.Secret("").String())
+}
+
+func (suite SecretSuite) TestBind() {
+	_ = os.Setenv("SECRET_PASSWORD", "hunter2")
+	_ = os.Setenv("SECRET_API_KEY", "abc123")
+	_ = os.Setenv("SECRET_PIN", "12x4")
+	_ = os.Setenv("SECRET_TOKEN", "not-in-list")
+	_ = os.Setenv("SECRET_USER", "admin")
+
+	config := struct {
+		User     string
+		Password configify.Secret
+		APIKey   string `conf:"API_KEY,secret"`
+		PIN      int    `conf:"PIN,secret"`
+		Token    string `conf:"TOKEN,secret" oneof:"a,b"`
+		Missing  configify.Secret
+	}{}
+
+	report, err := configify.NewBinder(configify.Environment(configify.Namespace("SECRET"))).BindReport(&config)
+	suite.Equal(configify.Secret("hunter2"), config.Password)
+	suite.Equal("abc123", config.APIKey)
+	suite.Equal("not-in-list", config.Token)
+
+	// The values of secrets never appear in errors...
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	suite.Require().Len(bindErr.Fields, 2)
+	suite.True(errors.Is(err, configify.ErrInvalidValue))
+	suite.Equal("[REDACTED]", bindErr.Fields[0].Value)
+	suite.Equal("[REDACTED]", bindErr.Fields[1].Value)
+	suite.NotContains(err.Error(), "12x4")
+	suite.NotContains(err.Error(), "not-in-list")
+
+	// ...or in reports.
+	values := map[string]string{}
+	for _, field := range report {
+		values[field.Field] = field.Value
+	}
+	suite.Equal(map[string]string{
+		"User":     "admin",
+		"Password": "[REDACTED]",
+		"APIKey":   "[REDACTED]",
+		"PIN":      "[REDACTED]",
+		"Token":    "[REDACTED]",
+		"Missing":  "",
+	}, values)
+	suite.NotContains(report.String(), "hunter2")
+}
+
+func (suite SecretSuite) TestBindNested() {
+	type dbConfig struct {
+		User     string
+		Password string
+	}
+	_ = os.Setenv("SECRET_NESTED_DB_USER", "admin")
+	_ = os.Setenv("SECRET_NESTED_DB_PASSWORD", "hunter2")
+	_ = os.Setenv("SECRET_NESTED_REPLICA_PASSWORD", "hunter3")
+
+	// Every field of a secret struct (or struct pointer) is a secret, no matter how deep it is.
+	config := struct {
+		DB      dbConfig  `conf:"DB,secret"`
+		Replica *dbConfig `conf:"REPLICA,secret"`
+	}{}
+	report, err := configify.NewBinder(configify.Environment(configify.Namespace("SECRET_NESTED"))).BindReport(&config)
+	suite.Require().NoError(err)
+	suite.Equal("hunter2", config.DB.Password)
+	suite.Require().NotNil(config.Replica)
+	suite.Equal("hunter3", config.Replica.Password)
+
+	suite.Equal(configify.Report{
+		{Field: "DB.User", Key: "SECRET_NESTED_DB_USER", Source: "env", Value: "[REDACTED]"},
+		{Field: "DB.Password", Key: "SECRET_NESTED_DB_PASSWORD", Source: "env", Value: "[REDACTED]"},
+		{Field: "Replica.User", Key: "SECRET_NESTED_REPLICA_USER"},
+		{Field: "Replica.Password", Key: "SECRET_NESTED_REPLICA_PASSWORD", Source: "env", Value: "[REDACTED]"},
+	}, report)
+	suite.NotContains(report.String(), "hunter")
+}
+
+func ExampleSecret() {
+	_ = os.Setenv("EXAMPLE_DB_PASSWORD", "hunter2")
+	config := struct {
+		Password configify.Secret `conf:"DB_PASSWORD"`
+	}{}
+	configify.NewBinder(configify.Environment(configify.Namespace("EXAMPLE"))).Bind(&config)
+
+	fmt.Println(config.Password)
+	fmt.Println(config.Password.Value())
+	// Output: [REDACTED]
+	// hunter2
+}
@@ -0,0 +1,97 @@
+package configify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Secrets creates a source that reads secrets that your container runtime or service manager exposes
+// as files, so passwords never need to pass through environment variables. Each key is a file in one
+// of these directories, checked in order:
+//
+//   - $CREDENTIALS_DIRECTORY: Where systemd puts credentials configured via LoadCredential= and friends.
+//   - /run/secrets: Where Docker and Docker Swarm mount secrets.
+//
+// The key is qualified by your namespace first, and we look for a file with that exact name before
+// trying the lower-case version, so the key "DB_PASSWORD" finds a Docker secret named "db_password".
+// Trailing newlines are stripped from the values. Files are read on every lookup, so rotated secrets
+// are picked up the next time you ask for them. Defaults supplies the values for keys that don't have
+// a file in any of the directories.
+func Secrets(opts ...Option) Source {
+	options := apply(opts, &Options{
+		Defaults: emptySource{},
+	})
+
+	source := &secretsSource{}
+	if credentials := os.Getenv("CREDENTIALS_DIRECTORY"); credentials != "" {
+		source.directories = append(source.directories, credentials)
+	}
+	source.directories = append(source.directories, "/run/secrets")
+	source.stringSource = stringSource{name: "secrets", options: *options, massage: Massage{Lenient: options.Lenient}, lookup: source.lookup}
+	return source
+}
+
+type secretsSource struct {
+	stringSource
+	directories []string
+}
+
+func (s *secretsSource) lookup(key string) (string, bool) {
+	name := s.options.Namespace.Qualify(key)
+	if !isSecretFileName(name) {
+		return "", false
+	}
+	for _, directory := range s.directories {
+		for _, fileName := range []string{name, strings.ToLower(name)} {
+			if value, err := readFileValue(filepath.Join(directory, fileName)); err == nil {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Keys lists the secrets in all of the directories. Files with lower-case names are listed using their
+// upper-case keys since that's how you'd typically look them up.
+func (s *secretsSource) Keys(prefix string) []string {
+	var keys []string
+	for _, directory := range s.directories {
+		entries, err := os.ReadDir(directory)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			if key, ok := s.fileKey(entry.Name()); ok && strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keysWithDefaults(keys, s.options.Defaults, prefix)
+}
+
+// fileKey converts a file name back into the key that finds it, mirroring lookup(): the file is
+// either named exactly like the qualified key or it's the lower-case version of that key. The
+// boolean is false when the file doesn't belong to our namespace.
+func (s *secretsSource) fileKey(name string) (string, bool) {
+	namespacePrefix := s.options.Namespace.Prefix()
+	if name == strings.ToLower(name) {
+		namespacePrefix = strings.ToLower(namespacePrefix)
+		if !strings.HasPrefix(name, namespacePrefix) {
+			return "", false
+		}
+		return strings.ToUpper(strings.TrimPrefix(name, namespacePrefix)), true
+	}
+	if !strings.HasPrefix(name, namespacePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, namespacePrefix), true
+}
+
+// isSecretFileName makes sure that a key can't be used to read files outside of the secret directories.
+func isSecretFileName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\`)
+}
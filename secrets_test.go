@@ -0,0 +1,121 @@
+package configify_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/robsignorelli/configify/configifytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSecretsSuite(t *testing.T) {
+	suite.Run(t, new(SecretsSuite))
+}
+
+type SecretsSuite struct {
+	configifytest.SourceSuite
+	path string
+}
+
+func (suite *SecretsSuite) SetupTest() {
+	suite.path = suite.T().TempDir()
+	suite.write("SECRETS_STRING", "foo\n")
+	suite.write("secrets_lower", "lower\n")
+	suite.write("SECRETS_BOTH", "upper")
+	suite.write("secrets_both", "lower")
+	suite.write("SECRETS_SPACE", " pass word \n")
+	suite.write("secrets_int", "5\n")
+	suite.write("secrets_timeout", "30s")
+	suite.write("OTHER_STRING", "other")
+	suite.write(".secrets_hidden", "hidden")
+
+	suite.T().Setenv("CREDENTIALS_DIRECTORY", suite.path)
+	suite.Source = configify.Secrets(configify.Namespace("SECRETS"))
+}
+
+func (suite SecretsSuite) write(name string, value string) {
+	suite.Require().NoError(os.WriteFile(filepath.Join(suite.path, name), []byte(value), 0600))
+}
+
+func (suite SecretsSuite) TestOptions() {
+	suite.Equal("SECRETS", suite.Source.Options().Namespace.Name)
+}
+
+func (suite SecretsSuite) TestLookup() {
+	suite.ExpectString("NOT_FOUND", "", false)
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectInt("INT", 5, true)
+	suite.ExpectDuration("TIMEOUT", 30*time.Second, true)
+
+	// We try the exact file name before the lower-case one.
+	suite.ExpectString("LOWER", "lower", true)
+	suite.ExpectString("BOTH", "upper", true)
+	suite.ExpectString("lower", "lower", true)
+
+	// Only trailing newlines are stripped since spaces might be part of the secret.
+	value, ok := suite.Source.String("SPACE")
+	suite.True(ok)
+	suite.Equal(" pass word ", value)
+
+	// Files outside of the namespace or directory are off limits.
+	suite.ExpectString("HIDDEN", "", false)
+	suite.ExpectString("OTHER_STRING", "", false)
+	suite.Source = configify.Secrets()
+	suite.ExpectString("OTHER_STRING", "other", true)
+	suite.ExpectString("../"+filepath.Base(suite.path)+"/OTHER_STRING", "", false)
+	suite.ExpectString(".secrets_hidden", "", false)
+}
+
+func (suite SecretsSuite) TestRotation() {
+	suite.ExpectString("STRING", "foo", true)
+	suite.write("SECRETS_STRING", "bar\n")
+	suite.ExpectString("STRING", "bar", true)
+}
+
+func (suite SecretsSuite) TestDefaults() {
+	suite.Source = configify.Secrets(
+		configify.Namespace("SECRETS"),
+		configify.Defaults(configify.Values{"STRING": "default", "NEW": "fallback"}))
+	suite.ExpectString("STRING", "foo", true)
+	suite.ExpectString("NEW", "fallback", true)
+	suite.Equal(configify.Origin{Source: "secrets", Key: "SECRETS_STRING", Found: true}, configify.Explain(suite.Source, "STRING"))
+}
+
+func (suite SecretsSuite) TestKeys() {
+	enumerable, ok := suite.Source.(configify.Enumerable)
+	suite.Require().True(ok, "Secrets should be enumerable")
+
+	// Lower-case file names are listed using the upper-case keys that find them.
+	keys := enumerable.Keys("")
+	suite.Subset(keys, []string{"BOTH", "INT", "LOWER", "SPACE", "STRING", "TIMEOUT"})
+	suite.NotContains(keys, "OTHER_STRING")
+	suite.NotContains(keys, "HIDDEN")
+	suite.Equal([]string{"STRING"}, enumerable.Keys("STR"))
+
+	// Keys match the namespace the same way that lookups do, regardless of the namespace's case.
+	suite.write("myapp_db_password", "hunter2")
+	suite.write("myapp_DB_USER", "admin")
+	source := configify.Secrets(configify.Namespace("myapp"))
+	suite.Equal(map[string]string{"DB_PASSWORD": "hunter2", "DB_USER": "admin"}, configify.All(source))
+	suite.Equal([]string{"DB_PASSWORD"}, source.(configify.Enumerable).Keys("DB_P"))
+}
+
+func ExampleSecrets() {
+	// systemd sets this when you use LoadCredential=db_password:/path/to/password
+	dir, _ := os.MkdirTemp("", "credentials")
+	defer os.RemoveAll(dir)
+	_ = os.WriteFile(filepath.Join(dir, "db_password"), []byte("hunter2\n"), 0600)
+	_ = os.Setenv("CREDENTIALS_DIRECTORY", dir)
+	defer os.Unsetenv("CREDENTIALS_DIRECTORY")
+
+	config := struct {
+		DBPassword configify.Secret `conf:"DB_PASSWORD"`
+	}{}
+	configify.NewBinder(configify.Secrets()).Bind(&config)
+	fmt.Println(config.DBPassword.Value())
+	// Output: hunter2
+}
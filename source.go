@@ -2,6 +2,7 @@ package configify
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 )
@@ -42,10 +43,71 @@ type SourceWatcher interface {
 	Watch(callback func(source Source))
 }
 
+// Enumerable defines a Source that can list the keys it has values for rather than only supporting
+// point lookups. Not all sources support this, so check using a type assertion.
+type Enumerable interface {
+	Source
+	// Keys returns the sorted, unqualified names of all keys that start with the given prefix. Pass
+	// an empty string to get all of them.
+	Keys(prefix string) []string
+}
+
+// All returns the raw string value for every key that the source can enumerate, keyed by the
+// unqualified key name. This is handy for dumping your effective config or for detecting stray keys
+// that nothing in your program uses. Sources that aren't Enumerable give you an empty map, as do
+// values that can't be represented as strings.
+func All(source Source) map[string]string {
+	values := map[string]string{}
+	enumerable, ok := source.(Enumerable)
+	if !ok {
+		return values
+	}
+	for _, key := range enumerable.Keys("") {
+		if value, ok := source.String(key); ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// unqualifiedKeys filters the fully qualified key names down to the ones that start with the given
+// (unqualified) prefix, stripping the namespace from the ones it returns.
+func unqualifiedKeys(ns namespace, names []string, prefix string) []string {
+	qualifiedPrefix := ns.Prefix() + prefix
+	var keys []string
+	for _, name := range names {
+		if strings.HasPrefix(name, qualifiedPrefix) {
+			keys = append(keys, strings.TrimPrefix(name, ns.Prefix()))
+		}
+	}
+	return keys
+}
+
+// keysWithDefaults merges the keys from the source's Defaults (if it can enumerate them) into the
+// keys that the source itself found, since lookups on the source fall back to those values as well.
+func keysWithDefaults(keys []string, defaults Source, prefix string) []string {
+	if enumerable, ok := defaults.(Enumerable); ok {
+		keys = append(keys, enumerable.Keys(prefix)...)
+	}
+	return uniqueSorted(keys)
+}
+
+func uniqueSorted(keys []string) []string {
+	sort.Strings(keys)
+	unique := keys[:0]
+	for i, key := range keys {
+		if i == 0 || key != keys[i-1] {
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
 // Option defines a functional option setting you can utilize when configuring a new source.
 type Option func(*Options)
 
-// Defaults applies the following fallback values to the source you're creating.
+// Defaults applies the following fallback values to the source you're creating. Nested Values are
+// flattened using the source's namespace delimiter, just like they are in Map().
 func Defaults(values Values) Option {
 	return func(options *Options) {
 		options.Defaults = Map(values)
@@ -105,10 +167,34 @@ func RefreshInterval(interval time.Duration) Option {
 	}
 }
 
+// Lenient allows sources that parse integers from strings to truncate decimal values such as "12.9"
+// to 12. By default, those values are treated as invalid integers rather than silently losing data.
+func Lenient() Option {
+	return func(options *Options) {
+		options.Lenient = true
+	}
+}
+
+// FileIndirection lets the Environment source read a value from a file when the variable itself isn't
+// set but one with the given suffix is. With FileIndirection("_FILE"), "DB_PASSWORD_FILE=/run/secrets/db"
+// means "read DB_PASSWORD from /run/secrets/db", which is a convention that many Docker images follow.
+func FileIndirection(suffix string) Option {
+	return func(options *Options) {
+		options.FileIndirection = strings.TrimSpace(suffix)
+	}
+}
+
 func apply(options []Option, defaults *Options) *Options {
 	for _, option := range options {
 		option(defaults)
 	}
+
+	// Nested default values should be joined using this source's delimiter regardless of whether
+	// you supplied NamespaceDelim() before or after Defaults().
+	if values, ok := defaults.Defaults.(*mapSource); ok && values.options.Namespace.Delimiter == "" {
+		delimiter := defaults.Namespace.delimiter()
+		defaults.Defaults = Map(values.nested, NamespaceDelim(delimiter))
+	}
 	return defaults
 }
 
@@ -154,6 +240,14 @@ type Options struct {
 	// RefreshInterval, for implementations that support it, indicates how frequently you want the
 	// underlying source to check for modifications.
 	RefreshInterval time.Duration
+
+	// Lenient, for sources that parse values from strings, allows integers to be parsed from values
+	// that have a fractional part by truncating them (e.g. "12.9" becomes 12).
+	Lenient bool
+
+	// FileIndirection, for the Environment source, is the suffix of variables that contain the path to
+	// a file holding the value rather than the value itself (e.g. "_FILE"). Empty disables this.
+	FileIndirection string
 }
 
 // namespace defines a fixed prefix for keys in your config store. This helps you isolate your
@@ -178,10 +272,7 @@ func (ns namespace) Qualify(key string) string {
 // will ensure that there are no consecutive delimiters or leading/trailing ones. This does NOT
 // force the namespace name as a prefix!
 func (ns namespace) Join(segments ...string) string {
-	delim := strings.TrimSpace(ns.Delimiter)
-	if delim == "" {
-		delim = "_"
-	}
+	delim := ns.delimiter()
 	var goodSegments []string
 	for _, segment := range segments {
 		if segment = strings.TrimSpace(segment); segment != "" {
@@ -191,5 +282,22 @@ func (ns namespace) Join(segments ...string) string {
 	return strings.Join(goodSegments, delim)
 }
 
+// Prefix returns the leading portion that all namespace-qualified keys share (e.g. "HTTP_"). This
+// is an empty string when there is no namespace.
+func (ns namespace) Prefix() string {
+	name := strings.TrimSpace(ns.Name)
+	if name == "" {
+		return ""
+	}
+	return name + ns.delimiter()
+}
+
+func (ns namespace) delimiter() string {
+	if delim := strings.TrimSpace(ns.Delimiter); delim != "" {
+		return delim
+	}
+	return "_"
+}
+
 // Values represents a set of key/value pairs as a map.
 type Values map[string]interface{}
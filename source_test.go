@@ -113,3 +113,14 @@ func (suite OptionsSuite) TestRefreshInterval() {
 	configify.RefreshInterval(-5 * time.Second)(&options)
 	suite.Equal(0*time.Second, options.RefreshInterval)
 }
+
+func (suite OptionsSuite) TestFileIndirection() {
+	options := configify.Options{}
+	suite.Empty(options.FileIndirection)
+
+	configify.FileIndirection(" _FILE ")(&options)
+	suite.Equal("_FILE", options.FileIndirection)
+
+	configify.FileIndirection("")(&options)
+	suite.Empty(options.FileIndirection)
+}
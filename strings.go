@@ -0,0 +1,189 @@
+package configify
+
+import (
+	"strconv"
+	"time"
+)
+
+// stringSource is the shared implementation for any source whose raw values are natively
+// strings (environment variables, dotenv files, etc). The only thing that differs between
+// those sources is how we fetch the raw value, so they supply a 'lookup' function and this
+// handles all of the parsing and fallback-to-defaults behavior.
+type stringSource struct {
+	name    string
+	options Options
+	massage Massage
+	lookup  func(key string) (string, bool)
+}
+
+// textSource creates a string-backed source that returns the same raw value no matter which key you
+// ask for. The binder uses this to parse values that don't come from your real source (such as the
+// default values in your struct tags) using the exact same rules as values that do.
+func textSource(options Options, value string) Source {
+	options.Defaults = emptySource{}
+	return &stringSource{
+		name:    "default",
+		options: options,
+		massage: Massage{Lenient: options.Lenient},
+		lookup: func(string) (string, bool) {
+			return value, true
+		},
+	}
+}
+
+func (s stringSource) Options() Options {
+	return s.options
+}
+
+// Explain reports whether the value came from this source or from its Defaults.
+func (s stringSource) Explain(key string) Origin {
+	if _, ok := s.lookup(key); ok {
+		return Origin{Source: s.name, Key: s.options.Namespace.Qualify(key), Found: true}
+	}
+	return explainDefaults(s.options, key)
+}
+
+func (s stringSource) String(key string) (string, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.String(key)
+	}
+	return value, true
+}
+
+func (s stringSource) StringSlice(key string) ([]string, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.StringSlice(key)
+	}
+	return s.massage.StringToSlice(value)
+}
+
+func (s stringSource) Int(key string) (int, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Int(key)
+	}
+	number, ok := s.massage.StringToIntSize(value, strconv.IntSize)
+	return int(number), ok
+}
+
+func (s stringSource) Int8(key string) (int8, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Int8(key)
+	}
+	number, ok := s.massage.StringToIntSize(value, 8)
+	return int8(number), ok
+}
+
+func (s stringSource) Int16(key string) (int16, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Int16(key)
+	}
+	number, ok := s.massage.StringToIntSize(value, 16)
+	return int16(number), ok
+}
+
+func (s stringSource) Int32(key string) (int32, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Int32(key)
+	}
+	number, ok := s.massage.StringToIntSize(value, 32)
+	return int32(number), ok
+}
+
+func (s stringSource) Int64(key string) (int64, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Int64(key)
+	}
+	return s.massage.StringToInt64(value)
+}
+
+func (s stringSource) Uint(key string) (uint, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Uint(key)
+	}
+	number, ok := s.massage.StringToUintSize(value, strconv.IntSize)
+	return uint(number), ok
+}
+
+func (s stringSource) Uint8(key string) (uint8, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Uint8(key)
+	}
+	number, ok := s.massage.StringToUintSize(value, 8)
+	return uint8(number), ok
+}
+
+func (s stringSource) Uint16(key string) (uint16, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Uint16(key)
+	}
+	number, ok := s.massage.StringToUintSize(value, 16)
+	return uint16(number), ok
+}
+
+func (s stringSource) Uint32(key string) (uint32, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Uint32(key)
+	}
+	number, ok := s.massage.StringToUintSize(value, 32)
+	return uint32(number), ok
+}
+
+func (s stringSource) Uint64(key string) (uint64, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Uint64(key)
+	}
+	return s.massage.StringToUint64(value)
+}
+
+func (s stringSource) Float32(key string) (float32, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Float32(key)
+	}
+	number, ok := s.massage.StringToFloatSize(value, 32)
+	return float32(number), ok
+}
+
+func (s stringSource) Float64(key string) (float64, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Float64(key)
+	}
+	return s.massage.StringToFloat64(value)
+}
+
+func (s stringSource) Bool(key string) (bool, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Bool(key)
+	}
+	return s.massage.StringToBool(value)
+}
+
+func (s stringSource) Duration(key string) (time.Duration, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Duration(key)
+	}
+	return s.massage.StringToDuration(value)
+}
+
+func (s stringSource) Time(key string) (time.Time, bool) {
+	value, ok := s.lookup(key)
+	if !ok {
+		return s.options.Defaults.Time(key)
+	}
+	return s.massage.StringToTime(value)
+}
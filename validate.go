@@ -0,0 +1,281 @@
+package configify
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Validator is implemented by config structs that want to check their own values once they're bound.
+// The binder calls Validate() on every struct it populates (including nested ones) after binding all
+// of its fields, so you can enforce rules that span multiple fields. Any error you return is reported
+// by BindE alongside the other field errors.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError describes a field whose value was bound successfully, but that breaks one of the
+// validation rules defined in its struct tags. The binder supports the following rules:
+//
+//   - nonempty:"true" - Strings, slices and maps must have a length; pointers must not be nil; all
+//     other values must not be the zero value for their type.
+//   - len:"5" - Strings (in characters), slices and maps must have exactly this length.
+//   - min:"1" - Numbers must be at least this value. Durations use duration strings (e.g. "1s").
+//     For strings, slices and maps this is the minimum length.
+//   - max:"65535" - Numbers must be at most this value. Durations use duration strings (e.g. "5m").
+//     For strings, slices and maps this is the maximum length.
+//   - oneof:"debug,info,warn" - The value must be one of the comma-separated options.
+//   - pattern:"^[a-z]+$" - Strings must match the regular expression.
+//
+// When the field is a slice, the oneof and pattern rules are applied to each element.
+type ValidationError struct {
+	// Rule is the name of the struct tag whose rule was broken (e.g. "min").
+	Rule string
+	// Message describes what's wrong with the value.
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// validationRules are the struct tags we check, in the order we check them.
+var validationRules = []struct {
+	name  string
+	check func(value reflect.Value, ruleValue string) (string, error)
+}{
+	{name: "nonempty", check: checkNonEmpty},
+	{name: "len", check: checkLen},
+	{name: "min", check: checkMin},
+	{name: "max", check: checkMax},
+	{name: "oneof", check: checkOneOf},
+	{name: "pattern", check: checkPattern},
+}
+
+// validateField checks the field's value against all of the rules defined in its struct tags,
+// recording an error for each one that it breaks.
+func (b standardBinder) validateField(field reflect.StructField, value reflect.Value, key string, path string) {
+	for _, rule := range validationRules {
+		ruleValue, ok := field.Tag.Lookup(rule.name)
+		if !ok {
+			continue
+		}
+
+		message, err := rule.check(value, ruleValue)
+		if err != nil {
+			message = fmt.Sprintf("invalid '%s' rule '%s': %v", rule.name, ruleValue, err)
+		}
+		if message != "" {
+			b.errors.add(FieldError{
+				Field: path,
+				Key:   b.qualify(key),
+				Value: formatValue(value),
+				Type:  field.Type,
+				Err:   ValidationError{Rule: rule.name, Message: message},
+			})
+		}
+	}
+}
+
+// validateStruct invokes the struct's Validate() hook if it has one.
+func (b standardBinder) validateStruct(value reflect.Value, key string, path string) {
+	if !value.CanAddr() {
+		return
+	}
+	validator, ok := value.Addr().Interface().(Validator)
+	if !ok {
+		return
+	}
+	if err := validator.Validate(); err != nil {
+		b.errors.add(FieldError{
+			Field: path,
+			Key:   b.qualify(key),
+			Type:  value.Type(),
+			Err:   err,
+		})
+	}
+}
+
+func checkNonEmpty(value reflect.Value, ruleValue string) (string, error) {
+	enabled, err := strconv.ParseBool(ruleValue)
+	if err != nil || !enabled {
+		return "", err
+	}
+
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if value.Len() == 0 {
+			return "must not be empty", nil
+		}
+	default:
+		if value.IsZero() {
+			return "must not be empty", nil
+		}
+	}
+	return "", nil
+}
+
+func checkLen(value reflect.Value, ruleValue string) (string, error) {
+	expected, err := strconv.Atoi(ruleValue)
+	if err != nil {
+		return "", err
+	}
+	if length, ok := valueLength(indirect(value)); ok && length != expected {
+		return fmt.Sprintf("length must be %d", expected), nil
+	}
+	return "", nil
+}
+
+func checkMin(value reflect.Value, ruleValue string) (string, error) {
+	comparison, err := compareValue(indirect(value), ruleValue)
+	if err != nil || comparison >= 0 {
+		return "", err
+	}
+	if _, ok := valueLength(indirect(value)); ok {
+		return fmt.Sprintf("length must be at least %s", ruleValue), nil
+	}
+	return fmt.Sprintf("must be at least %s", ruleValue), nil
+}
+
+func checkMax(value reflect.Value, ruleValue string) (string, error) {
+	comparison, err := compareValue(indirect(value), ruleValue)
+	if err != nil || comparison <= 0 {
+		return "", err
+	}
+	if _, ok := valueLength(indirect(value)); ok {
+		return fmt.Sprintf("length must be at most %s", ruleValue), nil
+	}
+	return fmt.Sprintf("must be at most %s", ruleValue), nil
+}
+
+func checkOneOf(value reflect.Value, ruleValue string) (string, error) {
+	options := strings.Split(ruleValue, ",")
+	for i := range options {
+		options[i] = strings.TrimSpace(options[i])
+	}
+
+	for _, element := range elements(indirect(value)) {
+		if !containsString(options, formatValue(element)) {
+			return fmt.Sprintf("must be one of [%s]", strings.Join(options, ", ")), nil
+		}
+	}
+	return "", nil
+}
+
+func checkPattern(value reflect.Value, ruleValue string) (string, error) {
+	pattern, err := regexp.Compile(ruleValue)
+	if err != nil {
+		return "", err
+	}
+	for _, element := range elements(indirect(value)) {
+		if element.Kind() == reflect.String && !pattern.MatchString(element.String()) {
+			return fmt.Sprintf("must match the pattern %s", ruleValue), nil
+		}
+	}
+	return "", nil
+}
+
+// compareValue returns -1, 0, or 1 depending on whether the value (or its length) is less than, equal
+// to, or greater than the rule value. Values of types that don't support comparison return 0.
+func compareValue(value reflect.Value, ruleValue string) (int, error) {
+	if length, ok := valueLength(value); ok {
+		limit, err := strconv.Atoi(ruleValue)
+		return compareOrdered(int64(length), int64(limit)), err
+	}
+	if !value.IsValid() {
+		return 0, nil
+	}
+
+	if value.Type() == typeDuration {
+		limit, err := time.ParseDuration(ruleValue)
+		return compareOrdered(value.Int(), int64(limit)), err
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		limit, err := strconv.ParseInt(ruleValue, 10, 64)
+		return compareOrdered(value.Int(), limit), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		limit, err := strconv.ParseUint(ruleValue, 10, 64)
+		return compareOrdered(value.Uint(), limit), err
+	case reflect.Float32, reflect.Float64:
+		limit, err := strconv.ParseFloat(ruleValue, 64)
+		return compareOrdered(value.Float(), limit), err
+	}
+	return 0, nil
+}
+
+func compareOrdered[T int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// valueLength returns the length of strings (in characters), slices and maps. The boolean is false
+// for any other kind of value.
+func valueLength(value reflect.Value) (int, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len(), true
+	}
+	return 0, false
+}
+
+// elements returns the individual values of a slice/array, or just the value itself for anything else.
+func elements(value reflect.Value) []reflect.Value {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Slice, reflect.Array:
+		values := make([]reflect.Value, value.Len())
+		for i := range values {
+			values[i] = indirect(value.Index(i))
+		}
+		return values
+	}
+	return []reflect.Value{value}
+}
+
+// indirect follows pointers until it reaches a non-pointer value. A nil pointer gives you an
+// invalid reflect.Value, which none of the rules apply to.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// formatValue converts the value to the same sort of string you might have supplied in the source.
+// String types use their raw value, even if they have a String() method (e.g. Secret).
+func formatValue(value reflect.Value) string {
+	value = indirect(value)
+	if !value.IsValid() || !value.CanInterface() {
+		return ""
+	}
+	if value.Kind() == reflect.String {
+		return value.String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
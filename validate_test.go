@@ -0,0 +1,217 @@
+package configify_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robsignorelli/configify"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateSuite))
+}
+
+type ValidateSuite struct {
+	suite.Suite
+}
+
+type ValidatedConfig struct {
+	Port     int           `min:"1" max:"65535"`
+	Ratio    float64       `min:"0" max:"1"`
+	Workers  uint8         `min:"2"`
+	Timeout  time.Duration `min:"1s" max:"1m"`
+	Mode     string        `oneof:"debug, info,warn"`
+	Name     string        `nonempty:"true" pattern:"^[a-z]+$"`
+	Code     string        `len:"3"`
+	Labels   []string      `nonempty:"true" max:"2" oneof:"a,b,c"`
+	Hosts    []string      `pattern:"^[a-z.]+$"`
+	Token    *string       `nonempty:"true"`
+	Optional *int          `min:"5"`
+	Skipped  string        `nonempty:"false"`
+	Nested   ValidatedNested
+}
+
+type ValidatedNested struct {
+	Min int
+	Max int
+}
+
+func (n ValidatedNested) Validate() error {
+	if n.Min > n.Max {
+		return errors.New("min must not be greater than max")
+	}
+	return nil
+}
+
+// validatedRoot ensures that we run the Validate() hook on the root struct using a pointer receiver.
+type validatedRoot struct {
+	Enabled bool
+	URL     string
+}
+
+func (r *validatedRoot) Validate() error {
+	if r.Enabled && r.URL == "" {
+		return errors.New("url is required when enabled")
+	}
+	return nil
+}
+
+// bind populates the struct using environment variables in the "VALIDATE" namespace, so we're
+// validating the same sorts of string values you'd get in production.
+func (suite ValidateSuite) bind(out interface{}, values map[string]string) *configify.BindError {
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, "VALIDATE_") {
+			_ = os.Unsetenv(strings.SplitN(env, "=", 2)[0])
+		}
+	}
+	for key, value := range values {
+		_ = os.Setenv("VALIDATE_"+key, value)
+	}
+
+	err := configify.NewBinder(configify.Environment(configify.Namespace("VALIDATE"))).BindE(out)
+	if err == nil {
+		return nil
+	}
+	bindErr, ok := err.(*configify.BindError)
+	suite.Require().True(ok, "Should be a *BindError")
+	return bindErr
+}
+
+func (suite ValidateSuite) validValues() map[string]string {
+	return map[string]string{
+		"PORT":       "8080",
+		"RATIO":      "0.5",
+		"WORKERS":    "2",
+		"TIMEOUT":    "30s",
+		"MODE":       "info",
+		"NAME":       "hello",
+		"CODE":       "abc",
+		"LABELS":     "a,c",
+		"HOSTS":      "example.com,localhost",
+		"TOKEN":      "",
+		"NESTED_MIN": "1",
+		"NESTED_MAX": "2",
+	}
+}
+
+func (suite ValidateSuite) TestValid() {
+	config := ValidatedConfig{}
+	suite.Nil(suite.bind(&config, suite.validValues()))
+	suite.Equal(8080, config.Port)
+}
+
+func (suite ValidateSuite) TestInvalid() {
+	config := ValidatedConfig{}
+	bindErr := suite.bind(&config, map[string]string{
+		"PORT":       "70000",
+		"RATIO":      "-0.1",
+		"WORKERS":    "1",
+		"TIMEOUT":    "2m",
+		"MODE":       "trace",
+		"NAME":       "Hello",
+		"CODE":       "abcd",
+		"LABELS":     "a,b,d",
+		"HOSTS":      "example.com,LOCALHOST",
+		"OPTIONAL":   "4",
+		"NESTED_MIN": "3",
+		"NESTED_MAX": "2",
+	})
+	suite.Require().NotNil(bindErr)
+
+	messages := map[string]string{}
+	for _, field := range bindErr.Fields {
+		messages[field.Key] += field.Err.Error()
+	}
+	suite.Equal(map[string]string{
+		"VALIDATE_PORT":     "must be at most 65535",
+		"VALIDATE_RATIO":    "must be at least 0",
+		"VALIDATE_WORKERS":  "must be at least 2",
+		"VALIDATE_TIMEOUT":  "must be at most 1m",
+		"VALIDATE_MODE":     "must be one of [debug, info, warn]",
+		"VALIDATE_NAME":     "must match the pattern ^[a-z]+$",
+		"VALIDATE_CODE":     "length must be 3",
+		"VALIDATE_LABELS":   "length must be at most 2must be one of [a, b, c]",
+		"VALIDATE_HOSTS":    "must match the pattern ^[a-z.]+$",
+		"VALIDATE_TOKEN":    "must not be empty",
+		"VALIDATE_OPTIONAL": "must be at least 5",
+		"VALIDATE_NESTED":   "min must not be greater than max",
+	}, messages)
+
+	// Make sure that we have all of the structured info about the failures.
+	portErr := bindErr.Fields[0]
+	suite.Equal("Port", portErr.Field)
+	suite.Equal("70000", portErr.Value)
+	var validationErr configify.ValidationError
+	suite.Require().True(errors.As(portErr, &validationErr))
+	suite.Equal("max", validationErr.Rule)
+	suite.Equal("VALIDATE_NESTED", bindErr.Fields[len(bindErr.Fields)-1].Key)
+	suite.Equal("Nested", bindErr.Fields[len(bindErr.Fields)-1].Field)
+}
+
+func (suite ValidateSuite) TestEmpty() {
+	config := ValidatedConfig{}
+	bindErr := suite.bind(&config, map[string]string{})
+	suite.Require().NotNil(bindErr)
+
+	// Rules still apply to values that weren't in the source.
+	var keys []string
+	for _, field := range bindErr.Fields {
+		keys = append(keys, field.Field)
+	}
+	suite.Equal([]string{"Port", "Workers", "Timeout", "Mode", "Name", "Name", "Code", "Labels", "Token"}, keys)
+}
+
+func (suite ValidateSuite) TestSkipBadValues() {
+	// We don't bother validating values that we couldn't parse in the first place.
+	config := struct {
+		Port int `min:"1"`
+		Host string
+	}{}
+	bindErr := suite.bind(&config, map[string]string{"PORT": "abc"})
+	suite.Require().NotNil(bindErr)
+	suite.Require().Len(bindErr.Fields, 1)
+	suite.True(errors.Is(bindErr, configify.ErrInvalidValue))
+}
+
+func (suite ValidateSuite) TestInvalidRules() {
+	config := struct {
+		Port int    `min:"one"`
+		Name string `pattern:"[a-z"`
+	}{}
+	bindErr := suite.bind(&config, map[string]string{"PORT": "5", "NAME": "foo"})
+	suite.Require().NotNil(bindErr)
+	suite.Require().Len(bindErr.Fields, 2)
+	suite.Contains(bindErr.Fields[0].Error(), "invalid 'min' rule 'one'")
+	suite.Contains(bindErr.Fields[1].Error(), "invalid 'pattern' rule '[a-z'")
+}
+
+func (suite ValidateSuite) TestValidateHook() {
+	config := validatedRoot{}
+	suite.Nil(suite.bind(&config, map[string]string{"ENABLED": "false"}))
+
+	bindErr := suite.bind(&config, map[string]string{"ENABLED": "true"})
+	suite.Require().NotNil(bindErr)
+	suite.Require().Len(bindErr.Fields, 1)
+	suite.Equal("", bindErr.Fields[0].Field)
+	suite.Equal("url is required when enabled", bindErr.Fields[0].Err.Error())
+}
+
+func ExampleValidator() {
+	config := struct {
+		Port int    `min:"1" max:"65535"`
+		Mode string `oneof:"debug,info,warn"`
+	}{}
+	source := configify.Map(configify.Values{
+		"PORT": 0,
+		"MODE": "trace",
+	})
+
+	err := configify.NewBinder(source).BindE(&config)
+	fmt.Println(err)
+	// Output: configify: PORT (Port): must be at least 1; MODE (Mode): must be one of [debug, info, warn]
+}